@@ -3,8 +3,11 @@ package main
 import (
 	"fmt"
 	"io/ioutil"
+	"net/url"
+	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/pelletier/go-toml"
@@ -16,6 +19,7 @@ import (
 	"github.com/mxpv/podsync/pkg/fs"
 	"github.com/mxpv/podsync/pkg/model"
 	"github.com/mxpv/podsync/pkg/ytdl"
+	"github.com/mxpv/podsync/services/telemetry"
 	"github.com/mxpv/podsync/services/web"
 )
 
@@ -35,6 +39,10 @@ type Config struct {
 	Tokens map[model.Provider]StringSlice `toml:"tokens"`
 	// Downloader (youtube-dl) configuration
 	Downloader ytdl.Config `toml:"downloader"`
+	// Telemetry is the optional anonymous usage reporting configuration (off by default)
+	Telemetry telemetry.Config `toml:"telemetry"`
+	// Artwork configures images generated for feeds without usable artwork
+	Artwork feed.ArtworkConfig `toml:"artwork"`
 }
 
 type Log struct {
@@ -75,6 +83,10 @@ func LoadConfig(path string) (*Config, error) {
 	return &config, nil
 }
 
+var regionReg = regexp.MustCompile(`^[a-zA-Z]{2}$`)
+
+const minAccessTokenLength = 16
+
 func (c *Config) validate() error {
 	var result *multierror.Error
 
@@ -111,6 +123,16 @@ func (c *Config) validate() error {
 		result = multierror.Append(result, errors.Errorf("unknown storage type: %s", c.Storage.Type))
 	}
 
+	if c.Telemetry.Enabled {
+		if u, err := url.Parse(c.Telemetry.Endpoint); err != nil || u.Scheme != "https" || u.Host == "" {
+			result = multierror.Append(result, errors.New("telemetry endpoint must be an absolute https URL"))
+		}
+	}
+
+	if c.Telemetry.Period < 0 {
+		result = multierror.Append(result, errors.New("telemetry period can't be negative"))
+	}
+
 	if len(c.Feeds) == 0 {
 		result = multierror.Append(result, errors.New("at least one feed must be specified"))
 	}
@@ -119,6 +141,76 @@ func (c *Config) validate() error {
 		if f.URL == "" {
 			result = multierror.Append(result, errors.Errorf("URL is required for %q", id))
 		}
+
+		if f.Custom.NewFeedURL != "" {
+			if u, err := url.Parse(f.Custom.NewFeedURL); err != nil || u.Scheme != "https" || u.Host == "" {
+				result = multierror.Append(result, errors.Errorf("new_feed_url must be an absolute https URL (%q)", id))
+			}
+		}
+
+		if _, err := feed.ParseFooter(f.Custom.DescriptionFooter); err != nil {
+			result = multierror.Append(result, errors.Wrapf(err, "invalid description footer for %q", id))
+		}
+
+		if f.AccessToken != "" {
+			if len(f.AccessToken) < minAccessTokenLength {
+				result = multierror.Append(result, errors.Errorf("access_token must be at least %d characters long (%q)", minAccessTokenLength, id))
+			}
+
+			if c.Storage.Type != "local" {
+				result = multierror.Append(result, errors.Errorf("access_token is only supported with local storage (%q)", id))
+			}
+
+			if f.OPML {
+				result = multierror.Append(result, errors.Errorf("feeds with access_token can't be included in OPML (%q)", id))
+			}
+		}
+
+		if auth := f.BasicAuth; auth.Username != "" || auth.Password != "" {
+			if auth.Username == "" || auth.Password == "" || strings.Contains(auth.Username, ":") {
+				result = multierror.Append(result, errors.Errorf("basic_auth requires username (without colons) and password (%q)", id))
+			}
+
+			if c.Storage.Type != "local" {
+				result = multierror.Append(result, errors.Errorf("basic_auth is only supported with local storage (%q)", id))
+			}
+		}
+
+		if f.PublishDelay < 0 {
+			result = multierror.Append(result, errors.Errorf("publish_delay can't be negative (%q)", id))
+		}
+
+		for _, filter := range []struct{ name, pattern string }{
+			{"title", f.Filters.Title},
+			{"not_title", f.Filters.NotTitle},
+			{"description", f.Filters.Description},
+			{"not_description", f.Filters.NotDescription},
+		} {
+			if _, err := regexp.Compile(filter.pattern); err != nil {
+				result = multierror.Append(result, errors.Wrapf(err, "invalid %s filter %q for %q", filter.name, filter.pattern, id))
+			}
+		}
+
+		if f.Filters.Region != "" && !regionReg.MatchString(f.Filters.Region) {
+			result = multierror.Append(result, errors.Errorf("region filter must be a two letter country code (%q)", id))
+		}
+
+		for _, clip := range []string{f.Intro, f.Outro} {
+			if clip == "" {
+				continue
+			}
+
+			if f.Format != model.FormatAudio {
+				result = multierror.Append(result, errors.Errorf("intro and outro are only supported for audio feeds (%q)", id))
+				break
+			}
+
+			if !strings.EqualFold(filepath.Ext(clip), ".mp3") {
+				result = multierror.Append(result, errors.Errorf("intro/outro must be an mp3 file: %s", clip))
+			} else if _, err := os.Stat(clip); err != nil {
+				result = multierror.Append(result, errors.Wrapf(err, "failed to find intro/outro for %q", id))
+			}
+		}
 	}
 
 	return result.ErrorOrNil()
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -120,6 +122,152 @@ data_dir = "/data"
 	require.Len(t, config.Tokens["vimeo"], 0)
 }
 
+func TestInvalidFilter(t *testing.T) {
+	const file = `
+[server]
+data_dir = "/data"
+
+[feeds]
+  [feeds.A]
+  url = "https://youtube.com/watch?v=ygIUF678y40"
+  filters = { title = "(trailer", not_title = "clip" }
+`
+	path := setup(t, file)
+	defer os.Remove(path)
+
+	_, err := LoadConfig(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid title filter "(trailer"`)
+	assert.NotContains(t, err.Error(), "not_title")
+}
+
+func TestInvalidDescriptionFooter(t *testing.T) {
+	const file = `
+[server]
+data_dir = "/data"
+
+[feeds]
+  [feeds.A]
+  url = "https://youtube.com/watch?v=ygIUF678y40"
+  custom = { description_footer = "Published by {{.Nope}}" }
+`
+	path := setup(t, file)
+	defer os.Remove(path)
+
+	_, err := LoadConfig(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid description footer for "A"`)
+	assert.Contains(t, err.Error(), "Nope")
+}
+
+func TestAccessTokenValidation(t *testing.T) {
+	const file = `
+[server]
+data_dir = "/data"
+
+[feeds]
+  [feeds.A]
+  url = "https://youtube.com/watch?v=ygIUF678y40"
+  access_token = "short"
+  opml = true
+`
+	path := setup(t, file)
+	defer os.Remove(path)
+
+	_, err := LoadConfig(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "access_token must be at least 16 characters long")
+	assert.Contains(t, err.Error(), "can't be included in OPML")
+}
+
+func TestIntroOutroValidation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "podsync-clips-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var (
+		mp3 = filepath.Join(dir, "intro.mp3")
+		wav = filepath.Join(dir, "intro.wav")
+	)
+
+	require.NoError(t, ioutil.WriteFile(mp3, []byte("mp3"), 0644))
+	require.NoError(t, ioutil.WriteFile(wav, []byte("wav"), 0644))
+
+	tests := []struct {
+		name   string
+		format string
+		intro  string
+		err    string
+	}{
+		{"valid", "audio", mp3, ""},
+		{"video feed", "video", mp3, "only supported for audio feeds"},
+		{"not mp3", "audio", wav, "must be an mp3 file"},
+		{"missing file", "audio", filepath.Join(dir, "missing.mp3"), "failed to find intro/outro"},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.name, func(t *testing.T) {
+			path := setup(t, fmt.Sprintf(`
+[server]
+data_dir = "/data"
+
+[feeds]
+  [feeds.A]
+  url = "https://youtube.com/watch?v=ygIUF678y40"
+  format = %q
+  intro = %q
+`, tst.format, tst.intro))
+			defer os.Remove(path)
+
+			_, err := LoadConfig(path)
+			if tst.err == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tst.err)
+			}
+		})
+	}
+}
+
+func TestNegativePublishDelay(t *testing.T) {
+	const file = `
+[server]
+data_dir = "/data"
+
+[feeds]
+  [feeds.A]
+  url = "https://youtube.com/watch?v=ygIUF678y40"
+  publish_delay = "-1h"
+`
+	path := setup(t, file)
+	defer os.Remove(path)
+
+	_, err := LoadConfig(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "publish_delay can't be negative")
+}
+
+func TestNegativeTelemetryPeriod(t *testing.T) {
+	const file = `
+[server]
+data_dir = "/data"
+
+[telemetry]
+period = "-24h"
+
+[feeds]
+  [feeds.A]
+  url = "https://youtube.com/watch?v=ygIUF678y40"
+`
+	path := setup(t, file)
+	defer os.Remove(path)
+
+	_, err := LoadConfig(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "telemetry period can't be negative")
+}
+
 func TestApplyDefaults(t *testing.T) {
 	const file = `
 [server]
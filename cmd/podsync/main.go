@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
@@ -12,6 +13,7 @@ import (
 	"github.com/jessevdk/go-flags"
 	"github.com/mxpv/podsync/pkg/feed"
 	"github.com/mxpv/podsync/pkg/model"
+	"github.com/mxpv/podsync/services/telemetry"
 	"github.com/mxpv/podsync/services/update"
 	"github.com/mxpv/podsync/services/web"
 	"github.com/robfig/cron/v3"
@@ -25,10 +27,11 @@ import (
 )
 
 type Opts struct {
-	ConfigPath string `long:"config" short:"c" default:"config.toml" env:"PODSYNC_CONFIG_PATH"`
-	Headless   bool   `long:"headless"`
-	Debug      bool   `long:"debug"`
-	NoBanner   bool   `long:"no-banner"`
+	ConfigPath     string `long:"config" short:"c" default:"config.toml" env:"PODSYNC_CONFIG_PATH"`
+	Headless       bool   `long:"headless"`
+	Debug          bool   `long:"debug"`
+	NoBanner       bool   `long:"no-banner"`
+	PrintTelemetry bool   `long:"print-telemetry"`
 }
 
 const banner = `
@@ -82,6 +85,25 @@ func main() {
 		log.WithError(err).Fatal("failed to load configuration file")
 	}
 
+	// Only show what would be sent: don't touch youtube-dl, and don't create or lock the database
+	if opts.PrintTelemetry {
+		instanceID, err := db.ReadInstanceID(&cfg.Database)
+		if err != nil {
+			if err != model.ErrNotFound {
+				log.WithError(err).Warn("failed to read instance ID")
+			}
+			instanceID = "unknown"
+		}
+
+		data, err := json.MarshalIndent(telemetry.NewPayload(instanceID, version, cfg.Storage.Type, cfg.Feeds), "", "  ")
+		if err != nil {
+			log.WithError(err).Fatal("failed to marshal telemetry payload")
+		}
+
+		fmt.Println(string(data))
+		return
+	}
+
 	if cfg.Log.Filename != "" {
 		log.Infof("Using log file: %s", cfg.Log.Filename)
 
@@ -105,6 +127,10 @@ func main() {
 		log.WithError(err).Fatal("youtube-dl error")
 	}
 
+	if err := ytdl.ValidateClips(ctx, cfg.Feeds); err != nil {
+		log.WithError(err).Fatal("invalid intro/outro")
+	}
+
 	database, err := db.NewBadger(&cfg.Database)
 	if err != nil {
 		log.WithError(err).Fatal("failed to open database")
@@ -115,6 +141,13 @@ func main() {
 		}
 	}()
 
+	instanceID, err := database.InstanceID()
+	if err != nil {
+		log.WithError(err).Fatal("failed to read instance ID")
+	}
+
+	payload := telemetry.NewPayload(instanceID, version, cfg.Storage.Type, cfg.Feeds)
+
 	var storage fs.Storage
 	switch cfg.Storage.Type {
 	case "local":
@@ -145,6 +178,15 @@ func main() {
 		log.WithError(err).Fatal("failed to create updater")
 	}
 
+	if cfg.Artwork.Logo != "" {
+		logo, err := feed.LoadLogo(cfg.Artwork.Logo)
+		if err != nil {
+			log.WithError(err).Fatal("failed to load artwork logo")
+		}
+
+		manager.SetLogo(logo)
+	}
+
 	// In Headless mode, do one round of feed updates and quit
 	if opts.Headless {
 		for _, feed := range cfg.Feeds {
@@ -159,6 +201,9 @@ func main() {
 	updates := make(chan *feed.Config, 16)
 	defer close(updates)
 
+	// Queue of feeds to rebuild once withheld episodes are due
+	rebuilds := make(chan *feed.Config, 16)
+
 	group, ctx := errgroup.WithContext(ctx)
 	defer func() {
 		if err := group.Wait(); err != nil && (err != context.Canceled && err != http.ErrServerClosed) {
@@ -167,6 +212,14 @@ func main() {
 		log.Info("gracefully stopped")
 	}()
 
+	// Publish withheld episodes as soon as they are due rather than on the next update
+	manager.NotifyPending(func(feed *feed.Config) {
+		select {
+		case rebuilds <- feed:
+		case <-ctx.Done():
+		}
+	})
+
 	// Create Cron
 	c := cron.New(cron.WithChain(cron.SkipIfStillRunning(cron.DiscardLogger)))
 	m := make(map[string]cron.EntryID)
@@ -181,6 +234,10 @@ func main() {
 				} else {
 					log.Infof("next update of %s: %s", feed.ID, c.Entry(m[feed.ID]).Next)
 				}
+			case feed := <-rebuilds:
+				if err := manager.Rebuild(ctx, feed); err != nil {
+					log.WithError(err).Errorf("failed to rebuild feed: %s", feed.URL)
+				}
 			case <-ctx.Done():
 				return ctx.Err()
 			}
@@ -221,12 +278,45 @@ func main() {
 		}
 	})
 
+	if cfg.Telemetry.Enabled {
+		log.Infof("anonymous telemetry is enabled, run with --print-telemetry to see what is sent")
+
+		reporter := telemetry.New(cfg.Telemetry, payload)
+		group.Go(func() error {
+			return reporter.Run(ctx)
+		})
+	}
+
 	if cfg.Storage.Type == "s3" {
 		return // S3 content is hosted externally
 	}
 
 	// Run web server
-	srv := web.New(cfg.Server, storage)
+	access := map[string]web.FeedAccess{}
+	for id, feed := range cfg.Feeds {
+		if feed.AccessToken != "" || feed.BasicAuth.Username != "" {
+			access[id] = web.FeedAccess{
+				Token:    feed.AccessToken,
+				Username: feed.BasicAuth.Username,
+				Password: feed.BasicAuth.Password,
+			}
+		}
+	}
+
+	srv := web.New(cfg.Server, storage, access, map[string]web.HealthCheck{
+		"database": func(_ctx context.Context) error {
+			_, err := database.InstanceID()
+			return err
+		},
+		"storage": func(_ctx context.Context) error {
+			dir, err := storage.Open("/")
+			if err != nil {
+				return err
+			}
+
+			return dir.Close()
+		},
+	})
 
 	group.Go(func() error {
 		log.Infof("running listener at %s", srv.Addr)
@@ -236,12 +326,8 @@ func main() {
 	group.Go(func() error {
 		// Shutdown web server
 		defer func() {
-			ctxShutDown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-			defer func() {
-				cancel()
-			}()
 			log.Info("shutting down web server")
-			if err := srv.Shutdown(ctxShutDown); err != nil {
+			if err := srv.Stop(); err != nil {
 				log.WithError(err).Error("server shutdown failed")
 			}
 		}()
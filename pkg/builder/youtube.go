@@ -39,8 +39,11 @@ type YouTubeBuilder struct {
 
 // Cost: 5 units (call method: 1, snippet: 2, contentDetails: 2)
 // See https://developers.google.com/youtube/v3/docs/channels/list#part
-func (yt *YouTubeBuilder) listChannels(ctx context.Context, linkType model.Type, id string, parts string) (*youtube.Channel, error) {
+func (yt *YouTubeBuilder) listChannels(ctx context.Context, linkType model.Type, id string, parts string, hl string) (*youtube.Channel, error) {
 	req := yt.client.Channels.List(parts)
+	if hl != "" {
+		req = req.Hl(hl)
+	}
 
 	switch linkType {
 	case model.TypeChannel:
@@ -66,8 +69,11 @@ func (yt *YouTubeBuilder) listChannels(ctx context.Context, linkType model.Type,
 
 // Cost: 3 units (call method: 1, snippet: 2)
 // See https://developers.google.com/youtube/v3/docs/playlists/list#part
-func (yt *YouTubeBuilder) listPlaylists(ctx context.Context, id, channelID string, parts string) (*youtube.Playlist, error) {
+func (yt *YouTubeBuilder) listPlaylists(ctx context.Context, id, channelID string, parts string, hl string) (*youtube.Playlist, error) {
 	req := yt.client.Playlists.List(parts)
+	if hl != "" {
+		req = req.Hl(hl)
+	}
 
 	if id != "" {
 		req = req.Id(id)
@@ -152,7 +158,7 @@ func (yt *YouTubeBuilder) GetVideoCount(ctx context.Context, info *model.Info) (
 	switch info.LinkType {
 	case model.TypeChannel, model.TypeUser:
 		// Cost: 3 units
-		if channel, err := yt.listChannels(ctx, info.LinkType, info.ItemID, "id,statistics"); err != nil {
+		if channel, err := yt.listChannels(ctx, info.LinkType, info.ItemID, "id,statistics", ""); err != nil {
 			return 0, err
 		} else { // nolint:golint
 			return channel.Statistics.VideoCount, nil
@@ -160,7 +166,7 @@ func (yt *YouTubeBuilder) GetVideoCount(ctx context.Context, info *model.Info) (
 
 	case model.TypePlaylist:
 		// Cost: 3 units
-		if playlist, err := yt.listPlaylists(ctx, info.ItemID, "", "id,contentDetails"); err != nil {
+		if playlist, err := yt.listPlaylists(ctx, info.ItemID, "", "id,contentDetails", ""); err != nil {
 			return 0, err
 		} else { // nolint:golint
 			return uint64(playlist.ContentDetails.ItemCount), nil
@@ -179,13 +185,12 @@ func (yt *YouTubeBuilder) queryFeed(ctx context.Context, feed *model.Feed, info
 	switch info.LinkType {
 	case model.TypeChannel, model.TypeUser:
 		// Cost: 5 units for channel or user
-		channel, err := yt.listChannels(ctx, info.LinkType, info.ItemID, "id,snippet,contentDetails")
+		channel, err := yt.listChannels(ctx, info.LinkType, info.ItemID, "id,snippet,contentDetails", feed.Language)
 		if err != nil {
 			return err
 		}
 
-		feed.Title = channel.Snippet.Title
-		feed.Description = channel.Snippet.Description
+		feed.Title, feed.Description = channelText(channel.Snippet)
 
 		if channel.Kind == "youtube#channel" {
 			feed.ItemURL = fmt.Sprintf("https://youtube.com/channel/%s", channel.Id)
@@ -207,13 +212,15 @@ func (yt *YouTubeBuilder) queryFeed(ctx context.Context, feed *model.Feed, info
 
 	case model.TypePlaylist:
 		// Cost: 3 units for playlist
-		playlist, err := yt.listPlaylists(ctx, info.ItemID, "", "id,snippet")
+		playlist, err := yt.listPlaylists(ctx, info.ItemID, "", "id,snippet", feed.Language)
 		if err != nil {
 			return err
 		}
 
-		feed.Title = fmt.Sprintf("%s: %s", playlist.Snippet.ChannelTitle, playlist.Snippet.Title)
-		feed.Description = playlist.Snippet.Description
+		playlistTitle, playlistDescription := playlistText(playlist.Snippet)
+
+		feed.Title = fmt.Sprintf("%s: %s", playlist.Snippet.ChannelTitle, playlistTitle)
+		feed.Description = playlistDescription
 
 		feed.ItemURL = fmt.Sprintf("https://youtube.com/playlist?list=%s", playlist.Id)
 		feed.ItemID = playlist.Id
@@ -288,7 +295,12 @@ func (yt *YouTubeBuilder) queryVideoDescriptions(ctx context.Context, playlist m
 
 	// Loop in each slices of 50 (or less) IDs and query their description
 	for _, idsI := range idsList {
-		req, err := yt.client.Videos.List("id,snippet,contentDetails").Id(idsI).Context(ctx).Do(yt.key)
+		call := yt.client.Videos.List("id,snippet,contentDetails").Id(idsI)
+		if feed.Language != "" {
+			call = call.Hl(feed.Language)
+		}
+
+		req, err := call.Context(ctx).Do(yt.key)
 		if err != nil {
 			return errors.Wrap(err, "failed to query video descriptions")
 		}
@@ -332,10 +344,12 @@ func (yt *YouTubeBuilder) queryVideoDescriptions(ctx context.Context, playlist m
 				size  = yt.getSize(seconds, feed)
 			)
 
-			feed.Episodes = append(feed.Episodes, &model.Episode{
+			title, description := videoText(snippet)
+
+			episode := &model.Episode{
 				ID:          video.Id,
-				Title:       snippet.Title,
-				Description: snippet.Description,
+				Title:       title,
+				Description: description,
 				Thumbnail:   image,
 				Duration:    seconds,
 				Size:        size,
@@ -343,7 +357,14 @@ func (yt *YouTubeBuilder) queryVideoDescriptions(ctx context.Context, playlist m
 				PubDate:     pubDate,
 				Order:       order,
 				Status:      model.EpisodeNew,
-			})
+			}
+
+			if video.ContentDetails != nil && video.ContentDetails.RegionRestriction != nil {
+				episode.AllowedRegions = video.ContentDetails.RegionRestriction.Allowed
+				episode.BlockedRegions = video.ContentDetails.RegionRestriction.Blocked
+			}
+
+			feed.Episodes = append(feed.Episodes, episode)
 		}
 	}
 
@@ -375,9 +396,13 @@ func (yt *YouTubeBuilder) queryItems(ctx context.Context, feed *model.Feed) erro
 		// Extract playlist snippets
 		for _, item := range items {
 			allSnippets = append(allSnippets, item.Snippet)
-			count++
 		}
 
+		// Same video might be added to a playlist more than once,
+		// drop copies before truncating to page size so they don't waste the window.
+		allSnippets = dedupeSnippets(allSnippets)
+		count = len(allSnippets)
+
 		if (feed.PlaylistSort != model.SortingDesc && count >= feed.PageSize) || token == "" {
 			break
 		}
@@ -404,6 +429,69 @@ func (yt *YouTubeBuilder) queryItems(ctx context.Context, feed *model.Feed) erro
 	return nil
 }
 
+func channelText(snippet *youtube.ChannelSnippet) (string, string) {
+	if localized := snippet.Localized; localized != nil {
+		return localizedText(localized.Title, snippet.Title), localizedText(localized.Description, snippet.Description)
+	}
+
+	return snippet.Title, snippet.Description
+}
+
+func playlistText(snippet *youtube.PlaylistSnippet) (string, string) {
+	if localized := snippet.Localized; localized != nil {
+		return localizedText(localized.Title, snippet.Title), localizedText(localized.Description, snippet.Description)
+	}
+
+	return snippet.Title, snippet.Description
+}
+
+func videoText(snippet *youtube.VideoSnippet) (string, string) {
+	if localized := snippet.Localized; localized != nil {
+		return localizedText(localized.Title, snippet.Title), localizedText(localized.Description, snippet.Description)
+	}
+
+	return snippet.Title, snippet.Description
+}
+
+// localizedText returns localized value when YouTube provides one (requested via hl parameter),
+// otherwise falls back to the default language value.
+func localizedText(localized, fallback string) string {
+	if localized != "" {
+		return localized
+	}
+
+	return fallback
+}
+
+// dedupeSnippets removes playlist items referring to the same video, keeping the earliest one.
+func dedupeSnippets(snippets []*youtube.PlaylistItemSnippet) []*youtube.PlaylistItemSnippet {
+	var (
+		result = make([]*youtube.PlaylistItemSnippet, 0, len(snippets))
+		index  = make(map[string]int, len(snippets))
+	)
+
+	for _, snippet := range snippets {
+		videoID := snippet.ResourceId.VideoId
+
+		idx, ok := index[videoID]
+		if !ok {
+			index[videoID] = len(result)
+			result = append(result, snippet)
+			continue
+		}
+
+		log.Debugf("dropping duplicate playlist item %q", videoID)
+
+		existing, err1 := time.Parse(time.RFC3339, result[idx].PublishedAt)
+		current, err2 := time.Parse(time.RFC3339, snippet.PublishedAt)
+		if err1 == nil && err2 == nil && current.Before(existing) {
+			result[idx] = snippet
+		}
+	}
+
+	return result
+}
+
 func (yt *YouTubeBuilder) Build(ctx context.Context, cfg *feed.Config) (*model.Feed, error) {
 	info, err := ParseURL(cfg.URL)
 	if err != nil {
@@ -420,6 +508,7 @@ func (yt *YouTubeBuilder) Build(ctx context.Context, cfg *feed.Config) (*model.F
 		PageSize:        cfg.PageSize,
 		PlaylistSort:    cfg.PlaylistSort,
 		PrivateFeed:     cfg.PrivateFeed,
+		Language:        cfg.Custom.Language,
 		UpdatedAt:       time.Now().UTC(),
 	}
 
@@ -8,6 +8,7 @@ import (
 	"github.com/mxpv/podsync/pkg/feed"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/api/youtube/v3"
 
 	"github.com/mxpv/podsync/pkg/model"
 )
@@ -25,11 +26,11 @@ func TestYT_QueryChannel(t *testing.T) {
 	builder, err := NewYouTubeBuilder(ytKey)
 	require.NoError(t, err)
 
-	channel, err := builder.listChannels(testCtx, model.TypeChannel, "UC2yTVSttx7lxAOAzx1opjoA", "id")
+	channel, err := builder.listChannels(testCtx, model.TypeChannel, "UC2yTVSttx7lxAOAzx1opjoA", "id", "")
 	require.NoError(t, err)
 	require.Equal(t, "UC2yTVSttx7lxAOAzx1opjoA", channel.Id)
 
-	channel, err = builder.listChannels(testCtx, model.TypeUser, "fxigr1", "id")
+	channel, err = builder.listChannels(testCtx, model.TypeUser, "fxigr1", "id", "")
 	require.NoError(t, err)
 	require.Equal(t, "UCr_fwF-n-2_olTYd-m3n32g", channel.Id)
 }
@@ -101,3 +102,69 @@ func TestYT_GetVideoCount(t *testing.T) {
 		})
 	}
 }
+
+func TestYT_DedupeSnippets(t *testing.T) {
+	snippet := func(videoID, publishedAt string) *youtube.PlaylistItemSnippet {
+		return &youtube.PlaylistItemSnippet{
+			PublishedAt: publishedAt,
+			ResourceId:  &youtube.ResourceId{VideoId: videoID},
+		}
+	}
+
+	result := dedupeSnippets([]*youtube.PlaylistItemSnippet{
+		snippet("1", "2020-01-03T00:00:00Z"),
+		snippet("2", "2020-01-02T00:00:00Z"),
+		snippet("1", "2020-01-01T00:00:00Z"),
+		snippet("3", "2020-01-04T00:00:00Z"),
+		snippet("2", "2020-01-05T00:00:00Z"),
+	})
+
+	require.Len(t, result, 3)
+	assert.EqualValues(t, "1", result[0].ResourceId.VideoId)
+	assert.EqualValues(t, "2020-01-01T00:00:00Z", result[0].PublishedAt)
+	assert.EqualValues(t, "2", result[1].ResourceId.VideoId)
+	assert.EqualValues(t, "2020-01-02T00:00:00Z", result[1].PublishedAt)
+	assert.EqualValues(t, "3", result[2].ResourceId.VideoId)
+}
+
+func TestYT_LocalizedText(t *testing.T) {
+	// Without hl YouTube returns no localization, with hl it may only have some of the fields translated
+	tests := []struct {
+		name        string
+		localized   *youtube.VideoLocalization
+		title       string
+		description string
+	}{
+		{"not localized", nil, "Default title", "Default description"},
+		{"localized", &youtube.VideoLocalization{Title: "Título", Description: "Descripción"}, "Título", "Descripción"},
+		{"partially localized", &youtube.VideoLocalization{Title: "Título"}, "Título", "Default description"},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.name, func(t *testing.T) {
+			title, description := videoText(&youtube.VideoSnippet{
+				Title:       "Default title",
+				Description: "Default description",
+				Localized:   tst.localized,
+			})
+			assert.Equal(t, tst.title, title)
+			assert.Equal(t, tst.description, description)
+
+			title, description = channelText(&youtube.ChannelSnippet{
+				Title:       "Default title",
+				Description: "Default description",
+				Localized:   (*youtube.ChannelLocalization)(tst.localized),
+			})
+			assert.Equal(t, tst.title, title)
+			assert.Equal(t, tst.description, description)
+
+			title, description = playlistText(&youtube.PlaylistSnippet{
+				Title:       "Default title",
+				Description: "Default description",
+				Localized:   (*youtube.PlaylistLocalization)(tst.localized),
+			})
+			assert.Equal(t, tst.title, title)
+			assert.Equal(t, tst.description, description)
+		})
+	}
+}
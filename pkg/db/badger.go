@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"crypto/rand"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -16,6 +17,7 @@ import (
 
 const (
 	versionPath   = "podsync/version"
+	instancePath  = "podsync/instance_id"
 	feedPrefix    = "feed/"
 	feedPath      = "feed/%s"
 	episodePrefix = "episode/%s/"
@@ -73,6 +75,19 @@ func NewBadger(config *Config) (*Badger, error) {
 		return nil, errors.Wrap(err, "failed to read database version")
 	}
 
+	if err := db.Update(func(txn *badger.Txn) error {
+		instanceID, err := newInstanceID()
+		if err != nil {
+			return err
+		}
+		if err := storage.setObj(txn, []byte(instancePath), instanceID, false); err != nil && err != model.ErrAlreadyExists {
+			return err
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to save instance ID")
+	}
+
 	return &Badger{db: db}, nil
 }
 
@@ -93,6 +108,45 @@ func (b *Badger) Version() (int, error) {
 	return version, err
 }
 
+func (b *Badger) InstanceID() (string, error) {
+	var (
+		instanceID string
+	)
+
+	err := b.db.View(func(txn *badger.Txn) error {
+		return b.getObj(txn, []byte(instancePath), &instanceID)
+	})
+
+	return instanceID, err
+}
+
+// ReadInstanceID reads instance ID without creating or modifying the database.
+// Returns model.ErrNotFound if the database hasn't been initialized yet.
+// Fails if the database is locked by a running instance.
+func ReadInstanceID(config *Config) (string, error) {
+	if _, err := os.Stat(config.Dir); os.IsNotExist(err) {
+		return "", model.ErrNotFound
+	}
+
+	opts := badger.DefaultOptions(config.Dir).
+		WithLogger(log.StandardLogger()).
+		WithReadOnly(true)
+
+	if config.Badger != nil && config.Badger.FileIO {
+		opts.ValueLogLoadingMode = options.FileIO
+	}
+
+	db, err := badger.Open(opts)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to open database in read-only mode")
+	}
+
+	defer db.Close()
+
+	storage := &Badger{db: db}
+	return storage.InstanceID()
+}
+
 func (b *Badger) AddFeed(_ context.Context, feedID string, feed *model.Feed) error {
 	return b.db.Update(func(txn *badger.Txn) error {
 		// Insert or update feed info
@@ -311,3 +365,16 @@ func (b *Badger) unmarshalObj(item *badger.Item, out interface{}) error {
 		return json.Unmarshal(val, out)
 	})
 }
+
+// newInstanceID generates random (version 4) UUID to identify this instance
+func newInstanceID() (string, error) {
+	var buf [16]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return "", errors.Wrap(err, "failed to generate instance ID")
+	}
+
+	buf[6] = (buf[6] & 0x0f) | 0x40
+	buf[8] = (buf[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", buf[0:4], buf[4:6], buf[6:8], buf[8:10], buf[10:16]), nil
+}
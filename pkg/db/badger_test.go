@@ -4,6 +4,7 @@ import (
 	"context"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -43,6 +44,53 @@ func TestBadger_Version(t *testing.T) {
 	assert.Equal(t, CurrentVersion, ver)
 }
 
+func TestBadger_InstanceID(t *testing.T) {
+	dir, err := ioutil.TempDir("", "podsync-badger-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	db, err := NewBadger(&Config{Dir: dir})
+	require.NoError(t, err)
+
+	instanceID, err := db.InstanceID()
+	assert.NoError(t, err)
+	assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, instanceID)
+
+	require.NoError(t, db.Close())
+
+	// Instance ID must survive restarts
+	db, err = NewBadger(&Config{Dir: dir})
+	require.NoError(t, err)
+	defer db.Close()
+
+	reopened, err := db.InstanceID()
+	assert.NoError(t, err)
+	assert.Equal(t, instanceID, reopened)
+}
+
+func TestReadInstanceID(t *testing.T) {
+	dir, err := ioutil.TempDir("", "podsync-badger-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	_, err = ReadInstanceID(&Config{Dir: filepath.Join(dir, "missing")})
+	assert.Equal(t, model.ErrNotFound, err)
+
+	_, err = os.Stat(filepath.Join(dir, "missing"))
+	assert.True(t, os.IsNotExist(err), "must not create database")
+
+	db, err := NewBadger(&Config{Dir: dir})
+	require.NoError(t, err)
+
+	instanceID, err := db.InstanceID()
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	readOnly, err := ReadInstanceID(&Config{Dir: dir})
+	assert.NoError(t, err)
+	assert.Equal(t, instanceID, readOnly)
+}
+
 func TestBadger_AddFeed(t *testing.T) {
 	dir, err := ioutil.TempDir("", "podsync-badger-")
 	assert.NoError(t, err)
@@ -16,6 +16,9 @@ type Storage interface {
 	Close() error
 	Version() (int, error)
 
+	// InstanceID returns random identifier generated when database was created
+	InstanceID() (string, error)
+
 	// AddFeed will:
 	// - Insert or update feed info
 	// - Append new episodes to the existing list of episodes (existing episodes are not overwritten!)
@@ -0,0 +1,274 @@
+package feed
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"hash/fnv"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif" // Decoders for provider artwork
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	// MinArtworkSize is the smallest artwork size accepted by podcast directories
+	MinArtworkSize = 1400
+	// MaxArtworkSize is the largest artwork size accepted by podcast directories
+	MaxArtworkSize = 3000
+
+	// Title longer than this is cut with an ellipsis
+	placeholderMaxLines = 4
+)
+
+// PlaceholderSizes are the sizes placeholder artwork is rendered at,
+// feeds reference the largest one.
+var PlaceholderSizes = []int{MinArtworkSize, MaxArtworkSize}
+
+// Logo is an instance logo drawn on placeholder artwork.
+type Logo struct {
+	image.Image
+	// Sum identifies logo contents, so placeholders are regenerated when the logo changes
+	Sum uint64
+}
+
+var (
+	titleFont     *opentype.Font
+	titleFontErr  error
+	titleFontOnce sync.Once
+)
+
+// PlaceholderArt renders a square PNG for feeds without usable artwork: title text over a background
+// derived from the title, and an optional logo above it.
+func PlaceholderArt(title string, logo *Logo, size int) ([]byte, error) {
+	var (
+		background = placeholderColor(title)
+		border     = color.RGBA{R: background.R / 2, G: background.G / 2, B: background.B / 2, A: 0xff}
+		frame      = size / 20
+		img        = image.NewRGBA(image.Rect(0, 0, size, size))
+		inner      = img.Bounds().Inset(frame)
+		textTop    = inner.Min.Y
+	)
+
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: border}, image.Point{}, draw.Src)
+	draw.Draw(img, inner, &image.Uniform{C: background}, image.Point{}, draw.Src)
+
+	if logo != nil {
+		// Keep logo aspect ratio within a square box in the upper part
+		var (
+			box    = size / 4
+			bounds = logo.Bounds()
+			width  = box
+			height = box
+		)
+
+		if bounds.Dx() > bounds.Dy() {
+			height = box * bounds.Dy() / bounds.Dx()
+		} else if bounds.Dy() > bounds.Dx() {
+			width = box * bounds.Dx() / bounds.Dy()
+		}
+
+		top := inner.Min.Y + frame
+		rect := image.Rect((size-width)/2, top+(box-height)/2, (size+width)/2, top+(box+height)/2)
+		xdraw.CatmullRom.Scale(img, rect, logo, bounds, xdraw.Over, nil)
+
+		textTop = top + box
+	}
+
+	if err := drawTitle(img, title, image.Rect(inner.Min.X+frame, textTop, inner.Max.X-frame, inner.Max.Y), size); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, errors.Wrap(err, "failed to encode placeholder artwork")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// PlaceholderName returns file name of placeholder artwork. It changes with the title and the logo,
+// so an existing file never has to be regenerated.
+func PlaceholderName(title string, logo *Logo, size int) string {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(title))
+	if logo != nil {
+		_, _ = fmt.Fprintf(hash, "\x00%x", logo.Sum)
+	}
+
+	return fmt.Sprintf("placeholder-%x-%d.png", hash.Sum64(), size)
+}
+
+// LoadLogo reads instance logo to draw on placeholder artwork.
+func LoadLogo(path string) (*Logo, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read logo")
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode logo %q", path)
+	}
+
+	hash := fnv.New64a()
+	_, _ = hash.Write(data)
+
+	return &Logo{Image: img, Sum: hash.Sum64()}, nil
+}
+
+// ArtworkSize fetches just enough of the image to return its dimensions.
+func ArtworkSize(ctx context.Context, client *http.Client, url string) (int, int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to create request")
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to fetch artwork")
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, 0, errors.Errorf("failed to fetch artwork: %s", resp.Status)
+	}
+
+	// Image headers are at the beginning, no need to download the whole image
+	config, _, err := image.DecodeConfig(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to decode artwork")
+	}
+
+	return config.Width, config.Height, nil
+}
+
+// drawTitle word wraps the title and centers it within rect.
+func drawTitle(img *image.RGBA, title string, rect image.Rectangle, size int) error {
+	titleFontOnce.Do(func() {
+		titleFont, titleFontErr = opentype.Parse(gobold.TTF)
+	})
+
+	if titleFontErr != nil {
+		return errors.Wrap(titleFontErr, "failed to parse font")
+	}
+
+	face, err := opentype.NewFace(titleFont, &opentype.FaceOptions{
+		Size:    float64(size) / 14,
+		DPI:     72,
+		Hinting: font.HintingFull,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to create font face")
+	}
+
+	defer face.Close()
+
+	var (
+		lines      = wrapTitle(face, title, fixed.I(rect.Dx()))
+		metrics    = face.Metrics()
+		lineHeight = metrics.Height.Ceil()
+		y          = rect.Min.Y + (rect.Dy()-lineHeight*len(lines))/2 + metrics.Ascent.Ceil()
+		drawer     = font.Drawer{Dst: img, Src: image.NewUniform(textColor(placeholderColor(title))), Face: face}
+	)
+
+	for _, line := range lines {
+		width := drawer.MeasureString(line)
+		drawer.Dot = fixed.Point26_6{
+			X: fixed.I(rect.Min.X) + (fixed.I(rect.Dx())-width)/2,
+			Y: fixed.I(y),
+		}
+
+		drawer.DrawString(line)
+		y += lineHeight
+	}
+
+	return nil
+}
+
+func wrapTitle(face font.Face, title string, width fixed.Int26_6) []string {
+	var (
+		lines []string
+		line  string
+	)
+
+	for _, word := range strings.Fields(title) {
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+
+		if font.MeasureString(face, candidate) <= width {
+			line = candidate
+			continue
+		}
+
+		if line != "" {
+			lines = append(lines, line)
+		}
+
+		line = fitLine(face, word, width)
+	}
+
+	if line != "" {
+		lines = append(lines, line)
+	}
+
+	if len(lines) > placeholderMaxLines {
+		lines = lines[:placeholderMaxLines]
+		lines[placeholderMaxLines-1] = fitLine(face, lines[placeholderMaxLines-1]+"…", width)
+	}
+
+	return lines
+}
+
+// fitLine cuts text with an ellipsis until it fits the width.
+func fitLine(face font.Face, text string, width fixed.Int26_6) string {
+	runes := []rune(strings.TrimSuffix(text, "…"))
+	for len(runes) > 0 && font.MeasureString(face, text) > width {
+		runes = runes[:len(runes)-1]
+		text = string(runes) + "…"
+	}
+
+	return text
+}
+
+func placeholderColor(title string) color.RGBA {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(title))
+	sum := hash.Sum32()
+
+	// Keep channels in the middle range, so the color is neither too dark nor washed out
+	return color.RGBA{
+		R: uint8(0x40 + sum&0x7f),
+		G: uint8(0x40 + (sum>>8)&0x7f),
+		B: uint8(0x40 + (sum>>16)&0x7f),
+		A: 0xff,
+	}
+}
+
+// textColor picks black or white text, whichever is more readable on the background.
+func textColor(background color.RGBA) color.Color {
+	luma := 299*int(background.R) + 587*int(background.G) + 114*int(background.B)
+	if luma > 150*1000 {
+		return color.Black
+	}
+
+	return color.White
+}
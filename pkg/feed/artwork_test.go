@@ -0,0 +1,164 @@
+package feed
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/opentype"
+)
+
+func TestPlaceholderArt(t *testing.T) {
+	for _, size := range PlaceholderSizes {
+		t.Run(fmt.Sprintf("%dx%d", size, size), func(t *testing.T) {
+			data, err := PlaceholderArt("Channel title", nil, size)
+			require.NoError(t, err)
+
+			img, err := png.Decode(bytes.NewReader(data))
+			require.NoError(t, err)
+
+			assert.Equal(t, size, img.Bounds().Dx())
+			assert.Equal(t, size, img.Bounds().Dy())
+
+			// Background is derived from the title, so it's stable across builds and changes with the title
+			background := placeholderColor("Channel title")
+			assert.Equal(t, background, img.At(size/10, size/10))
+			assert.NotEqual(t, background, placeholderColor("Another title"))
+
+			// Title is rendered in the middle
+			assert.Greater(t, countColor(img, textColor(background)), 0)
+
+			again, err := PlaceholderArt("Channel title", nil, size)
+			require.NoError(t, err)
+			assert.Equal(t, data, again)
+		})
+	}
+}
+
+func TestPlaceholderArtLogo(t *testing.T) {
+	logo := image.NewRGBA(image.Rect(0, 0, 20, 10))
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 20; x++ {
+			logo.Set(x, y, color.RGBA{R: 0xff, A: 0xff})
+		}
+	}
+
+	data, err := PlaceholderArt("", &Logo{Image: logo}, MinArtworkSize)
+	require.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	// Logo is scaled to a quarter of the artwork keeping aspect ratio
+	assert.Equal(t, MinArtworkSize/4*MinArtworkSize/8, countColor(img, color.RGBA{R: 0xff, A: 0xff}))
+}
+
+func TestWrapTitle(t *testing.T) {
+	data, err := PlaceholderArt("A very long title that has to be wrapped over several lines, "+
+		"and even then it doesn't fit so it ends with an ellipsis", nil, MinArtworkSize)
+	require.NoError(t, err)
+	assert.NotEmpty(t, data)
+
+	lines := wrapTitle(testFace(t), "Supercalifragilisticexpialidocious supercalifragilisticexpialidocious "+
+		"a b c d e f g h i j k l m n o p q r s t u v w x y z", 500<<6)
+	require.Len(t, lines, placeholderMaxLines)
+	assert.Contains(t, lines[0], "…")
+	assert.Contains(t, lines[placeholderMaxLines-1], "…")
+
+	assert.Empty(t, wrapTitle(testFace(t), "  ", 500<<6))
+}
+
+func TestPlaceholderName(t *testing.T) {
+	name := PlaceholderName("Channel title", nil, MaxArtworkSize)
+	assert.Regexp(t, `^placeholder-[0-9a-f]+-3000\.png$`, name)
+	assert.Equal(t, name, PlaceholderName("Channel title", nil, MaxArtworkSize))
+	assert.NotEqual(t, name, PlaceholderName("Channel title", nil, MinArtworkSize))
+	assert.NotEqual(t, name, PlaceholderName("Another title", nil, MaxArtworkSize))
+	assert.NotEqual(t, name, PlaceholderName("Channel title", &Logo{Sum: 1}, MaxArtworkSize))
+}
+
+func TestLoadLogo(t *testing.T) {
+	dir, err := ioutil.TempDir("", "podsync-logo-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 4, 2))))
+
+	path := filepath.Join(dir, "logo.png")
+	require.NoError(t, ioutil.WriteFile(path, buf.Bytes(), 0644))
+
+	logo, err := LoadLogo(path)
+	require.NoError(t, err)
+	assert.Equal(t, 4, logo.Bounds().Dx())
+	assert.NotZero(t, logo.Sum)
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("not an image"), 0644))
+	_, err = LoadLogo(path)
+	assert.Error(t, err)
+}
+
+func TestArtworkSize(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 88, 66))))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/avatar.png" {
+			http.NotFound(w, r)
+			return
+		}
+
+		_, _ = w.Write(buf.Bytes())
+	}))
+	defer server.Close()
+
+	width, height, err := ArtworkSize(context.Background(), server.Client(), server.URL+"/avatar.png")
+	require.NoError(t, err)
+	assert.Equal(t, 88, width)
+	assert.Equal(t, 66, height)
+
+	_, _, err = ArtworkSize(context.Background(), server.Client(), server.URL+"/missing.png")
+	assert.Error(t, err)
+}
+
+func testFace(t *testing.T) font.Face {
+	f, err := opentype.Parse(gobold.TTF)
+	require.NoError(t, err)
+
+	face, err := opentype.NewFace(f, &opentype.FaceOptions{Size: 50, DPI: 72})
+	require.NoError(t, err)
+
+	return face
+}
+
+func countColor(img image.Image, c color.Color) int {
+	var (
+		count      int
+		r, g, b, a = c.RGBA()
+	)
+
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r2, g2, b2, a2 := img.At(x, y).RGBA()
+			if r == r2 && g == g2 && b == b2 && a == a2 {
+				count++
+			}
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,129 @@
+package feed
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mxpv/podsync/pkg/model"
+)
+
+const atomNamespace = "http://www.w3.org/2005/Atom"
+
+type atomFeed struct {
+	XMLName   xml.Name    `xml:"feed"`
+	Namespace string      `xml:"xmlns,attr"`
+	ID        string      `xml:"id"`
+	Title     string      `xml:"title"`
+	Subtitle  string      `xml:"subtitle,omitempty"`
+	Updated   string      `xml:"updated"`
+	Generator string      `xml:"generator"`
+	Logo      string      `xml:"logo,omitempty"`
+	Author    atomPerson  `xml:"author"`
+	Links     []atomLink  `xml:"link"`
+	Entries   []atomEntry `xml:"entry"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+type atomLink struct {
+	Rel    string `xml:"rel,attr,omitempty"`
+	Href   string `xml:"href,attr"`
+	Type   string `xml:"type,attr,omitempty"`
+	Length int64  `xml:"length,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID        string     `xml:"id"`
+	Title     string     `xml:"title"`
+	Updated   string     `xml:"updated"`
+	Published string     `xml:"published"`
+	Summary   string     `xml:"summary,omitempty"`
+	Links     []atomLink `xml:"link"`
+}
+
+// BuildAtom renders the same episodes as Build, but as an Atom 1.0 document.
+// Entry IDs match RSS item GUIDs, so they stay stable between updates and across formats.
+func BuildAtom(_ctx context.Context, feed *model.Feed, cfg *Config, hostname string) ([]byte, error) {
+	var (
+		now     = time.Now().UTC()
+		selfURL = fmt.Sprintf("%s/%s.atom", strings.TrimRight(hostname, "/"), cfg.ID)
+	)
+
+	c, err := resolveContent(feed, cfg, now)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := feed.PubDate
+
+	doc := atomFeed{
+		Namespace: atomNamespace,
+		ID:        selfURL,
+		Title:     c.Title,
+		Subtitle:  c.Description,
+		Generator: "Podsync",
+		Logo:      c.CoverArt,
+		Author:    atomPerson{Name: c.Author},
+		Links: []atomLink{
+			{Rel: "self", Href: withToken(cfg, selfURL), Type: "application/atom+xml"},
+		},
+	}
+
+	if c.Link != "" {
+		doc.Links = append(doc.Links, atomLink{Rel: "alternate", Href: c.Link})
+	}
+
+	for _, episode := range c.Episodes {
+		if episode.PubDate.After(updated) {
+			updated = episode.PubDate
+		}
+
+		description, err := c.description(feed, episode)
+		if err != nil {
+			return nil, err
+		}
+
+		entry := atomEntry{
+			// Same as RSS item GUID, so clients can match episodes across formats
+			ID:        episode.ID,
+			Title:     episode.Title,
+			Updated:   episode.PubDate.UTC().Format(time.RFC3339),
+			Published: episode.PubDate.UTC().Format(time.RFC3339),
+			Summary:   description,
+			Links: []atomLink{
+				{
+					Rel:    "enclosure",
+					Href:   episodeURL(hostname, cfg, episode),
+					Type:   enclosureType(feed.Format).String(),
+					Length: episode.Size,
+				},
+			},
+		}
+
+		if episode.VideoURL != "" {
+			entry.Links = append(entry.Links, atomLink{Rel: "alternate", Href: episode.VideoURL})
+		}
+
+		doc.Entries = append(doc.Entries, entry)
+	}
+
+	if updated.IsZero() {
+		updated = now
+	}
+
+	doc.Updated = updated.UTC().Format(time.RFC3339)
+
+	out, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal atom feed")
+	}
+
+	return append([]byte(xml.Header), out...), nil
+}
@@ -0,0 +1,66 @@
+package feed
+
+import (
+	"context"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/mxpv/podsync/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildAtom(t *testing.T) {
+	pubDate := time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	feed := model.Feed{
+		Title:   "channel",
+		ItemURL: "https://youtube.com/channel/123",
+		Format:  model.FormatAudio,
+		Episodes: []*model.Episode{
+			{
+				ID:          "1",
+				Status:      model.EpisodeDownloaded,
+				Title:       "title",
+				Description: "description",
+				VideoURL:    "https://youtube.com/watch?v=1",
+				PubDate:     pubDate,
+				Size:        42,
+			},
+			{
+				ID:     "2",
+				Status: model.EpisodeNew,
+				Title:  "not downloaded",
+			},
+		},
+	}
+
+	cfg := Config{ID: "test", Format: model.FormatAudio}
+
+	out, err := BuildAtom(context.Background(), &feed, &cfg, "http://localhost/")
+	require.NoError(t, err)
+
+	var doc atomFeed
+	require.NoError(t, xml.Unmarshal(out, &doc))
+
+	assert.EqualValues(t, "channel", doc.Title)
+	assert.EqualValues(t, "http://localhost/test.atom", doc.ID)
+	assert.EqualValues(t, "2020-05-01T10:00:00Z", doc.Updated)
+
+	require.Len(t, doc.Entries, 1)
+	entry := doc.Entries[0]
+	assert.EqualValues(t, "2020-05-01T10:00:00Z", entry.Updated)
+
+	// Must match RSS, otherwise clients will see different episodes when switching formats
+	podcast, err := Build(context.Background(), &feed, &cfg, "http://localhost/")
+	require.NoError(t, err)
+	require.Len(t, podcast.Items, 1)
+	assert.EqualValues(t, podcast.Items[0].GUID, entry.ID)
+
+	require.Len(t, entry.Links, 2)
+	assert.EqualValues(t, "enclosure", entry.Links[0].Rel)
+	assert.EqualValues(t, "http://localhost/test/1.mp3", entry.Links[0].Href)
+	assert.EqualValues(t, "audio/mpeg", entry.Links[0].Type)
+	assert.EqualValues(t, 42, entry.Links[0].Length)
+}
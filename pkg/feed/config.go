@@ -22,6 +22,10 @@ type Config struct {
 	// Cron expression format is how often to check update
 	// NOTE: too often update check might drain your API token.
 	CronSchedule string `toml:"cron_schedule"`
+	// PublishDelay withholds episodes from the feed until they are older than this duration.
+	// Episodes are still downloaded right away and keep their original publish date.
+	// Withheld episodes count toward PageSize and Clean.KeepLast like any other episode.
+	PublishDelay time.Duration `toml:"publish_delay"`
 	// Quality to use for this feed
 	Quality model.Quality `toml:"quality"`
 	// Maximum height of video
@@ -36,10 +40,22 @@ type Config struct {
 	Custom Custom `toml:"custom"`
 	// List of additional youtube-dl arguments passed at download time
 	YouTubeDLArgs []string `toml:"youtube_dl_args"`
+	// Intro is an optional path to an mp3 clip prepended to every episode (audio feeds only)
+	Intro string `toml:"intro"`
+	// Outro is an optional path to an mp3 clip appended to every episode (audio feeds only)
+	Outro string `toml:"outro"`
 	// Included in OPML file
 	OPML bool `toml:"opml"`
+	// Also publish the feed as Atom 1.0 document ({FEED_ID}.atom)
+	Atom bool `toml:"atom"`
+	// Also publish the feed as JSON Feed 1.1 document ({FEED_ID}.json)
+	JSON bool `toml:"json"`
 	// Private feed (not indexed by podcast aggregators)
 	PrivateFeed bool `toml:"private_feed"`
+	// AccessToken protects the feed and its episodes, they are only served with a matching ?token= query parameter
+	AccessToken string `toml:"access_token"`
+	// BasicAuth protects feed files (but not episodes) with HTTP Basic authentication
+	BasicAuth BasicAuth `toml:"basic_auth"`
 	// Playlist sort
 	PlaylistSort model.Sorting `toml:"playlist_sort"`
 }
@@ -49,6 +65,8 @@ type Filters struct {
 	NotTitle       string `toml:"not_title"`
 	Description    string `toml:"description"`
 	NotDescription string `toml:"not_description"`
+	// Region skips episodes not playable in this country (ISO 3166-1 alpha-2 code, e.g. "US")
+	Region string `toml:"region"`
 	// More filters to be added here
 }
 
@@ -65,9 +83,24 @@ type Custom struct {
 	OwnerName       string        `toml:"ownerName"`
 	OwnerEmail      string        `toml:"ownerEmail"`
 	Link            string        `toml:"link"`
+	NewFeedURL      string        `toml:"new_feed_url"`
+	// DescriptionFooter is a text/template appended to every episode description.
+	// Available fields: .Title, .Channel, .URL and .PubDate
+	DescriptionFooter string `toml:"description_footer"`
+}
+
+type BasicAuth struct {
+	Username string `toml:"username"`
+	Password string `toml:"password"`
 }
 
 type Cleanup struct {
 	// KeepLast defines how many episodes to keep
 	KeepLast int `toml:"keep_last"`
 }
+
+// ArtworkConfig is the configuration of artwork generated for feeds without usable images
+type ArtworkConfig struct {
+	// Logo is an optional path to an image drawn above feed title
+	Logo string `toml:"logo"`
+}
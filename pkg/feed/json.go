@@ -0,0 +1,102 @@
+package feed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mxpv/podsync/pkg/model"
+)
+
+const jsonFeedVersion = "https://jsonfeed.org/version/1.1"
+
+type jsonFeed struct {
+	Version     string       `json:"version"`
+	Title       string       `json:"title"`
+	HomePageURL string       `json:"home_page_url,omitempty"`
+	FeedURL     string       `json:"feed_url"`
+	Description string       `json:"description,omitempty"`
+	Icon        string       `json:"icon,omitempty"`
+	Authors     []jsonAuthor `json:"authors,omitempty"`
+	Language    string       `json:"language,omitempty"`
+	Items       []jsonItem   `json:"items"`
+}
+
+type jsonAuthor struct {
+	Name string `json:"name"`
+}
+
+type jsonItem struct {
+	ID            string           `json:"id"`
+	URL           string           `json:"url,omitempty"`
+	Title         string           `json:"title"`
+	ContentText   string           `json:"content_text"`
+	Image         string           `json:"image,omitempty"`
+	DatePublished string           `json:"date_published"`
+	Attachments   []jsonAttachment `json:"attachments"`
+}
+
+type jsonAttachment struct {
+	URL               string `json:"url"`
+	MimeType          string `json:"mime_type"`
+	SizeInBytes       int64  `json:"size_in_bytes,omitempty"`
+	DurationInSeconds int64  `json:"duration_in_seconds,omitempty"`
+}
+
+// BuildJSON renders the same episodes as Build, but as a JSON Feed 1.1 document.
+// Item IDs, publish dates and enclosure URLs match the RSS feed, so switching clients doesn't re-download episodes.
+func BuildJSON(_ctx context.Context, feed *model.Feed, cfg *Config, hostname string) ([]byte, error) {
+	now := time.Now().UTC()
+
+	c, err := resolveContent(feed, cfg, now)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := jsonFeed{
+		Version:     jsonFeedVersion,
+		Title:       c.Title,
+		HomePageURL: c.Link,
+		FeedURL:     withToken(cfg, fmt.Sprintf("%s/%s.json", strings.TrimRight(hostname, "/"), cfg.ID)),
+		Description: c.Description,
+		Icon:        c.CoverArt,
+		Authors:     []jsonAuthor{{Name: c.Author}},
+		Language:    cfg.Custom.Language,
+		Items:       []jsonItem{},
+	}
+
+	for _, episode := range c.Episodes {
+		description, err := c.description(feed, episode)
+		if err != nil {
+			return nil, err
+		}
+
+		doc.Items = append(doc.Items, jsonItem{
+			ID:            episode.ID,
+			URL:           episode.VideoURL,
+			Title:         episode.Title,
+			ContentText:   description,
+			Image:         episode.Thumbnail,
+			DatePublished: episode.PubDate.UTC().Format(time.RFC3339),
+			Attachments: []jsonAttachment{
+				{
+					URL:               episodeURL(hostname, cfg, episode),
+					MimeType:          enclosureType(feed.Format).String(),
+					SizeInBytes:       episode.Size,
+					DurationInSeconds: episode.Duration,
+				},
+			},
+		})
+	}
+
+	out, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal JSON feed")
+	}
+
+	return out, nil
+}
@@ -0,0 +1,68 @@
+package feed
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mxpv/podsync/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildJSON(t *testing.T) {
+	pubDate := time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	feed := model.Feed{
+		Title:   "channel",
+		ItemURL: "https://youtube.com/channel/123",
+		Format:  model.FormatVideo,
+		Episodes: []*model.Episode{
+			{
+				ID:          "1",
+				Status:      model.EpisodeDownloaded,
+				Title:       "title",
+				Description: "description",
+				VideoURL:    "https://youtube.com/watch?v=1",
+				PubDate:     pubDate,
+				Duration:    60,
+				Size:        42,
+			},
+			{
+				ID:     "2",
+				Status: model.EpisodeNew,
+				Title:  "not downloaded",
+			},
+		},
+	}
+
+	cfg := Config{ID: "test", Format: model.FormatVideo}
+
+	out, err := BuildJSON(context.Background(), &feed, &cfg, "http://localhost/")
+	require.NoError(t, err)
+
+	var doc jsonFeed
+	require.NoError(t, json.Unmarshal(out, &doc))
+
+	assert.EqualValues(t, "https://jsonfeed.org/version/1.1", doc.Version)
+	assert.EqualValues(t, "channel", doc.Title)
+	assert.EqualValues(t, "http://localhost/test.json", doc.FeedURL)
+
+	require.Len(t, doc.Items, 1)
+	item := doc.Items[0]
+	assert.EqualValues(t, "2020-05-01T10:00:00Z", item.DatePublished)
+
+	require.Len(t, item.Attachments, 1)
+	assert.EqualValues(t, "video/mp4", item.Attachments[0].MimeType)
+	assert.EqualValues(t, 42, item.Attachments[0].SizeInBytes)
+	assert.EqualValues(t, 60, item.Attachments[0].DurationInSeconds)
+
+	// Must match RSS, otherwise clients will download episodes again when switching formats
+	podcast, err := Build(context.Background(), &feed, &cfg, "http://localhost/")
+	require.NoError(t, err)
+	require.Len(t, podcast.Items, 1)
+	assert.EqualValues(t, podcast.Items[0].GUID, item.ID)
+	assert.EqualValues(t, podcast.Items[0].Enclosure.URL, item.Attachments[0].URL)
+	assert.EqualValues(t, podcast.Items[0].PubDate.UTC().Format(time.RFC3339), item.DatePublished)
+}
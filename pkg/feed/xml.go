@@ -3,9 +3,12 @@ package feed
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
+	"net/url"
 	"sort"
 	"strconv"
 	"strings"
+	"text/template"
 	"time"
 
 	itunes "github.com/eduncan911/podcast"
@@ -36,35 +39,18 @@ func Build(_ctx context.Context, feed *model.Feed, cfg *Config, hostname string)
 		defaultCategory  = "TV & Film"
 	)
 
-	var (
-		now         = time.Now().UTC()
-		author      = feed.Title
-		title       = feed.Title
-		description = feed.Description
-		feedLink    = feed.ItemURL
-	)
-
-	if cfg.Custom.Author != "" {
-		author = cfg.Custom.Author
-	}
+	now := time.Now().UTC()
 
-	if cfg.Custom.Title != "" {
-		title = cfg.Custom.Title
-	}
-
-	if cfg.Custom.Description != "" {
-		description = cfg.Custom.Description
+	c, err := resolveContent(feed, cfg, now)
+	if err != nil {
+		return nil, err
 	}
 
-	if cfg.Custom.Link != "" {
-		feedLink = cfg.Custom.Link
-	}
-
-	p := itunes.New(title, feedLink, description, &feed.PubDate, &now)
+	p := itunes.New(c.Title, c.Link, c.Description, &feed.PubDate, &now)
 	p.Generator = podsyncGenerator
-	p.AddSubTitle(title)
-	p.IAuthor = author
-	p.AddSummary(description)
+	p.AddSubTitle(c.Title)
+	p.IAuthor = c.Author
+	p.AddSummary(c.Description)
 
 	if feed.PrivateFeed {
 		p.IBlock = "yes"
@@ -77,11 +63,7 @@ func Build(_ctx context.Context, feed *model.Feed, cfg *Config, hostname string)
 		}
 	}
 
-	if cfg.Custom.CoverArt != "" {
-		p.AddImage(cfg.Custom.CoverArt)
-	} else {
-		p.AddImage(feed.CoverArt)
-	}
+	p.AddImage(c.CoverArt)
 
 	if cfg.Custom.Category != "" {
 		p.AddCategory(cfg.Custom.Category, cfg.Custom.Subcategories)
@@ -99,47 +81,32 @@ func Build(_ctx context.Context, feed *model.Feed, cfg *Config, hostname string)
 		p.Language = cfg.Custom.Language
 	}
 
-	for _, episode := range feed.Episodes {
-		if episode.PubDate.IsZero() {
-			episode.PubDate = now
-		}
+	if cfg.Custom.NewFeedURL != "" {
+		p.INewFeedURL = cfg.Custom.NewFeedURL
 	}
 
-	// Sort all episodes in descending order
-	sort.Sort(timeSlice(feed.Episodes))
-
-	for i, episode := range feed.Episodes {
-		if episode.Status != model.EpisodeDownloaded {
-			// Skip episodes that are not yet downloaded or have been removed
-			continue
+	for i, episode := range c.Episodes {
+		description, err := c.description(feed, episode)
+		if err != nil {
+			return nil, err
 		}
 
 		item := itunes.Item{
 			GUID:        episode.ID,
 			Link:        episode.VideoURL,
 			Title:       episode.Title,
-			Description: episode.Description,
+			Description: description,
 			ISubtitle:   episode.Title,
 			// Some app prefer 1-based order
 			IOrder: strconv.Itoa(i + 1),
 		}
 
 		item.AddPubDate(&episode.PubDate)
-		item.AddSummary(episode.Description)
+		item.AddSummary(description)
 		item.AddImage(episode.Thumbnail)
 		item.AddDuration(episode.Duration)
 
-		enclosureType := itunes.MP4
-		if feed.Format == model.FormatAudio {
-			enclosureType = itunes.MP3
-		}
-
-		var (
-			episodeName = EpisodeName(cfg, episode)
-			downloadURL = fmt.Sprintf("%s/%s/%s", strings.TrimRight(hostname, "/"), cfg.ID, episodeName)
-		)
-
-		item.AddEnclosure(downloadURL, enclosureType, episode.Size)
+		item.AddEnclosure(episodeURL(hostname, cfg, episode), enclosureType(feed.Format), episode.Size)
 
 		// p.AddItem requires description to be not empty, use workaround
 		if item.Description == "" {
@@ -160,6 +127,226 @@ func Build(_ctx context.Context, feed *model.Feed, cfg *Config, hostname string)
 	return &p, nil
 }
 
+// content is feed metadata with custom overrides applied, along with the episodes to publish.
+// All feed formats are rendered from it, so they always agree on what a feed contains.
+type content struct {
+	Title       string
+	Author      string
+	Description string
+	Link        string
+	CoverArt    string
+
+	// Episodes are downloaded and released episodes, newest first.
+	// Episodes without a publish date are copies dated now, the feed model is left untouched.
+	Episodes []*model.Episode
+
+	footer *template.Template
+}
+
+func resolveContent(feed *model.Feed, cfg *Config, now time.Time) (*content, error) {
+	footer, err := ParseFooter(cfg.Custom.DescriptionFooter)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &content{
+		Title:       feed.Title,
+		Author:      feed.Title,
+		Description: feed.Description,
+		Link:        feed.ItemURL,
+		CoverArt:    CoverArt(feed, cfg, now),
+		Episodes:    make([]*model.Episode, 0, len(feed.Episodes)),
+		footer:      footer,
+	}
+
+	if cfg.Custom.Author != "" {
+		c.Author = cfg.Custom.Author
+	}
+
+	if cfg.Custom.Title != "" {
+		c.Title = cfg.Custom.Title
+	}
+
+	if cfg.Custom.Description != "" {
+		c.Description = cfg.Custom.Description
+	}
+
+	if cfg.Custom.Link != "" {
+		c.Link = cfg.Custom.Link
+	}
+
+	for _, episode := range feed.Episodes {
+		if episode.Status != model.EpisodeDownloaded {
+			// Skip episodes that are not yet downloaded or have been removed
+			continue
+		}
+
+		if isPending(cfg, episode, now) {
+			// Withhold episodes until publish delay elapses
+			continue
+		}
+
+		if episode.PubDate.IsZero() {
+			dated := *episode
+			dated.PubDate = now
+			episode = &dated
+		}
+
+		c.Episodes = append(c.Episodes, episode)
+	}
+
+	// Sort all episodes in descending order
+	sort.Sort(timeSlice(c.Episodes))
+
+	return c, nil
+}
+
+// description returns episode description with the configured footer appended.
+func (c *content) description(feed *model.Feed, episode *model.Episode) (string, error) {
+	return appendFooter(c.footer, feed, episode)
+}
+
+// isPending reports whether the episode is younger than the feed's publish delay.
+// Episodes without a publish date are never withheld.
+func isPending(cfg *Config, episode *model.Episode, now time.Time) bool {
+	if cfg.PublishDelay <= 0 || episode.PubDate.IsZero() {
+		return false
+	}
+
+	return episode.PubDate.Add(cfg.PublishDelay).After(now)
+}
+
+// Pending returns the number of downloaded episodes currently withheld by the publish delay
+// and the time the next one is going to be published.
+func Pending(feed *model.Feed, cfg *Config, now time.Time) (int, time.Time) {
+	var (
+		count int
+		next  time.Time
+	)
+
+	for _, episode := range feed.Episodes {
+		if episode.Status != model.EpisodeDownloaded || !isPending(cfg, episode, now) {
+			continue
+		}
+
+		count++
+
+		unlock := episode.PubDate.Add(cfg.PublishDelay)
+		if next.IsZero() || unlock.Before(next) {
+			next = unlock
+		}
+	}
+
+	return count, next
+}
+
+// CoverArt selects feed artwork: custom cover art, then the provider's channel/playlist image,
+// then the thumbnail of the newest published episode (some sources have no usable artwork).
+func CoverArt(feed *model.Feed, cfg *Config, now time.Time) string {
+	if cfg.Custom.CoverArt != "" {
+		return cfg.Custom.CoverArt
+	}
+
+	if feed.CoverArt != "" {
+		return feed.CoverArt
+	}
+
+	var newest *model.Episode
+	for _, episode := range feed.Episodes {
+		if episode.Thumbnail == "" || episode.Status != model.EpisodeDownloaded || isPending(cfg, episode, now) {
+			continue
+		}
+
+		if newest == nil || episode.PubDate.After(newest.PubDate) {
+			newest = episode
+		}
+	}
+
+	if newest != nil {
+		return newest.Thumbnail
+	}
+
+	return ""
+}
+
+// footerData is the set of fields available to description footer templates.
+type footerData struct {
+	Title   string
+	Channel string
+	URL     string
+	PubDate time.Time
+}
+
+// ParseFooter parses description footer template, returns nil when no footer is configured.
+// The template is also rendered once against empty data, so references to unknown fields
+// are reported here rather than when a feed is being built.
+func ParseFooter(text string) (*template.Template, error) {
+	if text == "" {
+		return nil, nil
+	}
+
+	tmpl, err := template.New("footer").Parse(text)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse description footer")
+	}
+
+	if err := tmpl.Execute(ioutil.Discard, footerData{}); err != nil {
+		return nil, errors.Wrap(err, "failed to render description footer")
+	}
+
+	return tmpl, nil
+}
+
+func appendFooter(footer *template.Template, feed *model.Feed, episode *model.Episode) (string, error) {
+	if footer == nil {
+		return episode.Description, nil
+	}
+
+	data := footerData{
+		Title:   episode.Title,
+		Channel: feed.Title,
+		URL:     episode.VideoURL,
+		PubDate: episode.PubDate,
+	}
+
+	var buf strings.Builder
+	if err := footer.Execute(&buf, data); err != nil {
+		return "", errors.Wrapf(err, "failed to render description footer (id %q)", episode.ID)
+	}
+
+	if episode.Description == "" {
+		return buf.String(), nil
+	}
+
+	return episode.Description + "\n\n" + buf.String(), nil
+}
+
+func enclosureType(format model.Format) itunes.EnclosureType {
+	if format == model.FormatAudio {
+		return itunes.MP3
+	}
+
+	return itunes.MP4
+}
+
+func episodeURL(hostname string, cfg *Config, episode *model.Episode) string {
+	return FileURL(hostname, cfg, EpisodeName(cfg, episode))
+}
+
+// FileURL returns a public link to a file stored in feed's directory.
+func FileURL(hostname string, cfg *Config, name string) string {
+	return withToken(cfg, fmt.Sprintf("%s/%s/%s", strings.TrimRight(hostname, "/"), cfg.ID, name))
+}
+
+// withToken appends feed's access token to the URL, so podcast apps can fetch protected files.
+func withToken(cfg *Config, link string) string {
+	if cfg.AccessToken == "" {
+		return link
+	}
+
+	return link + "?token=" + url.QueryEscape(cfg.AccessToken)
+}
+
 func EpisodeName(feedConfig *Config, episode *model.Episode) string {
 	ext := "mp4"
 	if feedConfig.Format == model.FormatAudio {
@@ -2,7 +2,9 @@ package feed
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	itunes "github.com/eduncan911/podcast"
 	"github.com/mxpv/podsync/pkg/model"
@@ -46,3 +48,195 @@ func TestBuildXML(t *testing.T) {
 	assert.EqualValues(t, out.Items[0].Enclosure.URL, "http://localhost/test/1.mp4")
 	assert.EqualValues(t, out.Items[0].Enclosure.Type, itunes.MP4)
 }
+
+func TestBuildXMLNewFeedURL(t *testing.T) {
+	feed := model.Feed{}
+
+	cfg := Config{ID: "test"}
+	out, err := Build(context.Background(), &feed, &cfg, "http://localhost/")
+	assert.NoError(t, err)
+	assert.Empty(t, out.INewFeedURL)
+
+	cfg.Custom.NewFeedURL = "https://example.org/feed.xml"
+	out, err = Build(context.Background(), &feed, &cfg, "http://localhost/")
+	assert.NoError(t, err)
+	assert.EqualValues(t, "https://example.org/feed.xml", out.INewFeedURL)
+	assert.Contains(t, out.String(), "<itunes:new-feed-url>https://example.org/feed.xml</itunes:new-feed-url>")
+}
+
+func TestBuildXMLPublishDelay(t *testing.T) {
+	now := time.Now().UTC()
+
+	feed := model.Feed{
+		Title: "channel",
+		Episodes: []*model.Episode{
+			{ID: "old", Title: "old", Status: model.EpisodeDownloaded, PubDate: now.Add(-48 * time.Hour), Thumbnail: "http://img/old.jpg"},
+			{ID: "new", Title: "new", Status: model.EpisodeDownloaded, PubDate: now.Add(-2 * time.Hour), Thumbnail: "http://img/new.jpg"},
+			{ID: "newer", Title: "newer", Status: model.EpisodeDownloaded, PubDate: now.Add(-1 * time.Hour)},
+			{ID: "undated", Title: "undated", Status: model.EpisodeDownloaded},
+		},
+	}
+
+	cfg := Config{ID: "test", PublishDelay: 24 * time.Hour}
+
+	count, next := Pending(&feed, &cfg, now)
+	assert.EqualValues(t, 2, count)
+	assert.True(t, next.Equal(now.Add(22*time.Hour)))
+
+	podcast, err := Build(context.Background(), &feed, &cfg, "http://localhost/")
+	require.NoError(t, err)
+
+	var ids []string
+	for _, item := range podcast.Items {
+		ids = append(ids, item.GUID)
+	}
+
+	assert.ElementsMatch(t, []string{"old", "undated"}, ids)
+
+	// Withheld episodes must not leak through the cover art fallback
+	require.NotNil(t, podcast.Image)
+	assert.EqualValues(t, "http://img/old.jpg", podcast.Image.URL)
+
+	atom, err := BuildAtom(context.Background(), &feed, &cfg, "http://localhost/")
+	require.NoError(t, err)
+	assert.Contains(t, string(atom), "http://img/old.jpg")
+	assert.NotContains(t, string(atom), "http://img/new.jpg")
+
+	cfg.PublishDelay = 0
+	count, _ = Pending(&feed, &cfg, now)
+	assert.Zero(t, count)
+}
+
+func TestBuildXMLAccessToken(t *testing.T) {
+	feed := model.Feed{
+		Title: "channel",
+		Episodes: []*model.Episode{
+			{ID: "1", Title: "title", Status: model.EpisodeDownloaded},
+		},
+	}
+
+	cfg := Config{ID: "test", AccessToken: "secret+token/0123"}
+
+	podcast, err := Build(context.Background(), &feed, &cfg, "http://localhost/")
+	require.NoError(t, err)
+	require.Len(t, podcast.Items, 1)
+	assert.EqualValues(t, "http://localhost/test/1.mp4?token=secret%2Btoken%2F0123", podcast.Items[0].Enclosure.URL)
+}
+
+func BenchmarkBuildFeed100Items(b *testing.B) {
+	feed := model.Feed{Title: "channel", Description: "description"}
+	for i := 0; i < 100; i++ {
+		feed.Episodes = append(feed.Episodes, &model.Episode{
+			ID:          fmt.Sprintf("episode-%d", i),
+			Status:      model.EpisodeDownloaded,
+			Title:       fmt.Sprintf("Episode %d", i),
+			Description: "description",
+			PubDate:     time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(i) * time.Hour),
+			Duration:    600,
+			Size:        1024,
+		})
+	}
+
+	cfg := Config{ID: "test"}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		out, err := Build(context.Background(), &feed, &cfg, "http://localhost/")
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		_ = out.Bytes()
+	}
+}
+
+func TestBuildXMLDescriptionFooter(t *testing.T) {
+	feed := model.Feed{
+		Title: "channel",
+		Episodes: []*model.Episode{
+			{
+				ID:          "1",
+				Status:      model.EpisodeDownloaded,
+				Title:       "title",
+				Description: "description",
+				VideoURL:    "https://youtube.com/watch?v=1",
+			},
+		},
+	}
+
+	cfg := Config{
+		ID:     "test",
+		Custom: Custom{DescriptionFooter: "Originally published by {{.Channel}}: {{.URL}}"},
+	}
+
+	out, err := Build(context.Background(), &feed, &cfg, "http://localhost/")
+	require.NoError(t, err)
+
+	require.Len(t, out.Items, 1)
+	assert.EqualValues(t, "description\n\nOriginally published by channel: https://youtube.com/watch?v=1", out.Items[0].Description)
+
+	for _, footer := range []string{"{{.Unknown", "{{.Nope}}"} {
+		cfg.Custom.DescriptionFooter = footer
+		_, err = Build(context.Background(), &feed, &cfg, "http://localhost/")
+		assert.Error(t, err, footer)
+	}
+}
+
+func TestBuildXMLCoverArtFallback(t *testing.T) {
+	feed := model.Feed{
+		Episodes: []*model.Episode{
+			{
+				ID:        "1",
+				Status:    model.EpisodeDownloaded,
+				Title:     "title 1",
+				Thumbnail: "http://img/1",
+				PubDate:   time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			{
+				ID:        "2",
+				Status:    model.EpisodeDownloaded,
+				Title:     "title 2",
+				Thumbnail: "http://img/2",
+				PubDate:   time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	cfg := Config{ID: "test"}
+
+	out, err := Build(context.Background(), &feed, &cfg, "http://localhost/")
+	require.NoError(t, err)
+	require.NotNil(t, out.IImage)
+	assert.EqualValues(t, "http://img/2", out.IImage.HREF)
+
+	feed.CoverArt = "http://img/channel"
+	out, err = Build(context.Background(), &feed, &cfg, "http://localhost/")
+	require.NoError(t, err)
+	assert.EqualValues(t, "http://img/channel", out.IImage.HREF)
+
+	cfg.Custom.CoverArt = "http://img/custom"
+	out, err = Build(context.Background(), &feed, &cfg, "http://localhost/")
+	require.NoError(t, err)
+	assert.EqualValues(t, "http://img/custom", out.IImage.HREF)
+}
+
+func TestBuildXMLUndatedEpisode(t *testing.T) {
+	feed := model.Feed{
+		Title: "channel",
+		Episodes: []*model.Episode{
+			{ID: "1", Status: model.EpisodeDownloaded, Title: "undated"},
+		},
+	}
+
+	cfg := Config{ID: "test"}
+
+	podcast, err := Build(context.Background(), &feed, &cfg, "http://localhost/")
+	require.NoError(t, err)
+	require.Len(t, podcast.Items, 1)
+	assert.NotEmpty(t, podcast.Items[0].PubDateFormatted)
+
+	// Building a feed must not modify the model, other formats are rendered from it too
+	assert.True(t, feed.Episodes[0].PubDate.IsZero())
+}
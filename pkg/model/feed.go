@@ -40,6 +40,9 @@ type Episode struct {
 	Size        int64         `json:"size"`
 	Order       string        `json:"order"`
 	Status      EpisodeStatus `json:"status"` // Disk status
+	// Region restrictions reported by the provider (ISO 3166-1 alpha-2 codes)
+	AllowedRegions []string `json:"allowed_regions,omitempty"`
+	BlockedRegions []string `json:"blocked_regions,omitempty"`
 }
 
 type Feed struct {
@@ -64,6 +67,7 @@ type Feed struct {
 	UpdatedAt       time.Time  `json:"updated_at"`
 	PlaylistSort    Sorting    `json:"playlist_sort"`
 	PrivateFeed     bool       `json:"private_feed"`
+	Language        string     `json:"language"` // Preferred language of titles and descriptions
 }
 
 type EpisodeStatus string
@@ -9,6 +9,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -23,6 +24,9 @@ import (
 const (
 	DefaultDownloadTimeout = 10 * time.Minute
 	UpdatePeriod           = 24 * time.Hour
+	// MaxClipDuration caps intro and outro length
+	MaxClipDuration = 5 * time.Minute
+	probeTimeout    = 30 * time.Second
 )
 
 var (
@@ -157,6 +161,8 @@ func (dl *YoutubeDl) Update(ctx context.Context) error {
 	return nil
 }
 
+// Download fetches episode to a temp file.
+// When intro or outro clips are added, episode.Duration is updated to the length of the joined file.
 func (dl *YoutubeDl) Download(ctx context.Context, feedConfig *feed.Config, episode *model.Episode) (r io.ReadCloser, err error) {
 	tmpDir, err := ioutil.TempDir("", "podsync-")
 	if err != nil {
@@ -200,6 +206,23 @@ func (dl *YoutubeDl) Download(ctx context.Context, feedConfig *feed.Config, epis
 	}
 	// filePath now with the final extension
 	filePath = filepath.Join(tmpDir, fmt.Sprintf("%s.%s", episode.ID, ext))
+
+	if feedConfig.Format == model.FormatAudio && (feedConfig.Intro != "" || feedConfig.Outro != "") {
+		joinedPath := filepath.Join(tmpDir, fmt.Sprintf("%s.joined.%s", episode.ID, ext))
+		if err := dl.join(ctx, feedConfig, filePath, joinedPath); err != nil {
+			return nil, err
+		}
+
+		filePath = joinedPath
+
+		// Episode is now longer by intro and outro, report the actual length in feeds
+		if duration, err := probeDuration(ctx, filePath); err != nil {
+			log.WithError(err).Warnf("failed to probe duration of %q", episode.ID)
+		} else {
+			episode.Duration = int64(duration.Round(time.Second).Seconds())
+		}
+	}
+
 	f, err := os.Open(filePath)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to open downloaded file")
@@ -221,6 +244,110 @@ func (dl *YoutubeDl) exec(ctx context.Context, args ...string) (string, error) {
 	return string(output), nil
 }
 
+// join concatenates feed's intro and outro clips around the downloaded episode using ffmpeg.
+// Only newly downloaded episodes are affected, files already on disk are left as is.
+func (dl *YoutubeDl) join(ctx context.Context, feedConfig *feed.Config, episodePath, outputFilePath string) error {
+	path, err := exec.LookPath("ffmpeg")
+	if err != nil {
+		return errors.Wrap(err, "ffmpeg is required to add intro/outro")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, dl.timeout)
+	defer cancel()
+
+	args := buildJoinArgs(feedConfig, episodePath, outputFilePath)
+
+	output, err := exec.CommandContext(ctx, path, args...).CombinedOutput()
+	if err != nil {
+		log.Error(string(output))
+		return errors.Wrap(err, "failed to add intro/outro")
+	}
+
+	return nil
+}
+
+// ValidateClips makes sure that intro and outro clips are readable by ffprobe and don't exceed MaxClipDuration.
+func ValidateClips(ctx context.Context, feeds map[string]*feed.Config) error {
+	for id, feedConfig := range feeds {
+		for _, clip := range []string{feedConfig.Intro, feedConfig.Outro} {
+			if clip == "" {
+				continue
+			}
+
+			duration, err := probeDuration(ctx, clip)
+			if err != nil {
+				return errors.Wrapf(err, "failed to probe intro/outro for %q", id)
+			}
+
+			if duration > MaxClipDuration {
+				return errors.Errorf("intro/outro %s is %s long, must not exceed %s (%q)", clip, duration, MaxClipDuration, id)
+			}
+		}
+	}
+
+	return nil
+}
+
+// probeDuration returns media file duration using ffprobe.
+func probeDuration(ctx context.Context, filePath string) (time.Duration, error) {
+	path, err := exec.LookPath("ffprobe")
+	if err != nil {
+		return 0, errors.Wrap(err, "ffprobe is required to add intro/outro")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, path,
+		"-v", "error",
+		"-show_entries", "format=duration",
+		"-of", "default=noprint_wrappers=1:nokey=1",
+		filePath).Output()
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to probe %s", filePath)
+	}
+
+	return parseProbeDuration(string(output))
+}
+
+func parseProbeDuration(output string) (time.Duration, error) {
+	seconds, err := strconv.ParseFloat(strings.TrimSpace(output), 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unexpected ffprobe output %q", output)
+	}
+
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+func buildJoinArgs(feedConfig *feed.Config, episodePath, outputFilePath string) []string {
+	var inputs []string
+
+	if feedConfig.Intro != "" {
+		inputs = append(inputs, feedConfig.Intro)
+	}
+
+	inputs = append(inputs, episodePath)
+
+	if feedConfig.Outro != "" {
+		inputs = append(inputs, feedConfig.Outro)
+	}
+
+	var (
+		args   = []string{"-y", "-loglevel", "error"}
+		filter strings.Builder
+	)
+
+	for idx, input := range inputs {
+		args = append(args, "-i", input)
+		filter.WriteString(fmt.Sprintf("[%d:a]", idx))
+	}
+
+	filter.WriteString(fmt.Sprintf("concat=n=%d:v=0:a=1[out]", len(inputs)))
+
+	args = append(args, "-filter_complex", filter.String(), "-map", "[out]", outputFilePath)
+	return args
+}
+
 func buildArgs(feedConfig *feed.Config, episode *model.Episode, outputFilePath string) []string {
 	var args []string
 
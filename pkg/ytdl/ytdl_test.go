@@ -2,6 +2,7 @@ package ytdl
 
 import (
 	"testing"
+	"time"
 
 	"github.com/mxpv/podsync/pkg/feed"
 	"github.com/mxpv/podsync/pkg/model"
@@ -118,3 +119,50 @@ func TestBuildArgs(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildJoinArgs(t *testing.T) {
+	tests := []struct {
+		name   string
+		intro  string
+		outro  string
+		expect []string
+	}{
+		{
+			name:   "Intro only",
+			intro:  "/clips/intro.mp3",
+			expect: []string{"-y", "-loglevel", "error", "-i", "/clips/intro.mp3", "-i", "/tmp/1.mp3", "-filter_complex", "[0:a][1:a]concat=n=2:v=0:a=1[out]", "-map", "[out]", "/tmp/2.mp3"},
+		},
+		{
+			name:   "Outro only",
+			outro:  "/clips/outro.mp3",
+			expect: []string{"-y", "-loglevel", "error", "-i", "/tmp/1.mp3", "-i", "/clips/outro.mp3", "-filter_complex", "[0:a][1:a]concat=n=2:v=0:a=1[out]", "-map", "[out]", "/tmp/2.mp3"},
+		},
+		{
+			name:   "Intro and outro",
+			intro:  "/clips/intro.mp3",
+			outro:  "/clips/outro.mp3",
+			expect: []string{"-y", "-loglevel", "error", "-i", "/clips/intro.mp3", "-i", "/tmp/1.mp3", "-i", "/clips/outro.mp3", "-filter_complex", "[0:a][1:a][2:a]concat=n=3:v=0:a=1[out]", "-map", "[out]", "/tmp/2.mp3"},
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.name, func(t *testing.T) {
+			result := buildJoinArgs(&feed.Config{
+				Format: model.FormatAudio,
+				Intro:  tst.intro,
+				Outro:  tst.outro,
+			}, "/tmp/1.mp3", "/tmp/2.mp3")
+
+			assert.EqualValues(t, tst.expect, result)
+		})
+	}
+}
+
+func TestParseProbeDuration(t *testing.T) {
+	duration, err := parseProbeDuration("12.480000\n")
+	assert.NoError(t, err)
+	assert.Equal(t, 12480*time.Millisecond, duration)
+
+	_, err = parseProbeDuration("N/A\n")
+	assert.Error(t, err)
+}
@@ -0,0 +1,180 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/mxpv/podsync/pkg/builder"
+	"github.com/mxpv/podsync/pkg/feed"
+	"github.com/mxpv/podsync/pkg/model"
+)
+
+const (
+	DefaultPeriod = 24 * time.Hour
+	sendTimeout   = 30 * time.Second
+)
+
+// Config is an opt-in anonymous usage reporting configuration
+type Config struct {
+	// Enabled turns reporting on, it's off by default
+	Enabled bool `toml:"enabled"`
+	// Endpoint is a HTTPS URL to send reports to
+	Endpoint string `toml:"endpoint"`
+	// Period is how often to send reports (once a day by default)
+	Period time.Duration `toml:"period"`
+}
+
+// Features is a bitmask of optional features used by at least one feed
+type Features uint32
+
+const (
+	FeatureOPML Features = 1 << iota
+	FeatureAtom
+	FeatureFilters
+	FeatureCleanup
+	FeatureCron
+	FeatureIntroOutro
+	FeatureDescriptionFooter
+	FeaturePrivateFeed
+	FeatureYouTubeDLArgs
+	FeatureJSON
+)
+
+// Payload is the complete report sent to the telemetry endpoint.
+// Never add URLs, feed IDs, titles or anything else that could identify users or content.
+type Payload struct {
+	InstanceID string                 `json:"instance_id"`
+	Version    string                 `json:"version"`
+	Feeds      map[model.Provider]int `json:"feeds"`
+	Features   Features               `json:"features"`
+	Storage    string                 `json:"storage"`
+}
+
+// NewPayload collects anonymous usage data from the feeds configuration
+func NewPayload(instanceID, version, storage string, feeds map[string]*feed.Config) Payload {
+	payload := Payload{
+		InstanceID: instanceID,
+		Version:    version,
+		Feeds:      map[model.Provider]int{},
+		Storage:    storage,
+	}
+
+	for _, cfg := range feeds {
+		if info, err := builder.ParseURL(cfg.URL); err == nil {
+			payload.Feeds[info.Provider]++
+		}
+
+		if cfg.OPML {
+			payload.Features |= FeatureOPML
+		}
+
+		if cfg.Atom {
+			payload.Features |= FeatureAtom
+		}
+
+		if cfg.JSON {
+			payload.Features |= FeatureJSON
+		}
+
+		if cfg.Filters != (feed.Filters{}) {
+			payload.Features |= FeatureFilters
+		}
+
+		if cfg.Clean.KeepLast > 0 {
+			payload.Features |= FeatureCleanup
+		}
+
+		if cfg.CronSchedule != "" {
+			payload.Features |= FeatureCron
+		}
+
+		if cfg.Intro != "" || cfg.Outro != "" {
+			payload.Features |= FeatureIntroOutro
+		}
+
+		if cfg.Custom.DescriptionFooter != "" {
+			payload.Features |= FeatureDescriptionFooter
+		}
+
+		if cfg.PrivateFeed {
+			payload.Features |= FeaturePrivateFeed
+		}
+
+		if len(cfg.YouTubeDLArgs) > 0 {
+			payload.Features |= FeatureYouTubeDLArgs
+		}
+	}
+
+	return payload
+}
+
+type Reporter struct {
+	cfg     Config
+	payload Payload
+	client  *http.Client
+}
+
+func New(cfg Config, payload Payload) *Reporter {
+	if cfg.Period == 0 {
+		cfg.Period = DefaultPeriod
+	}
+
+	return &Reporter{
+		cfg:     cfg,
+		payload: payload,
+		client:  &http.Client{Timeout: sendTimeout},
+	}
+}
+
+// Run sends a report right away and then periodically until the context is canceled.
+// Failures are logged at debug level only and never affect the rest of the app.
+func (r *Reporter) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.cfg.Period)
+	defer ticker.Stop()
+
+	for {
+		if err := r.send(ctx); err != nil {
+			log.WithError(err).Debug("failed to send telemetry")
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (r *Reporter) send(ctx context.Context) error {
+	data, err := json.Marshal(r.payload)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal telemetry payload")
+	}
+
+	log.Infof("sending telemetry to %s: %s", r.cfg.Endpoint, data)
+
+	req, err := http.NewRequest(http.MethodPost, r.cfg.Endpoint, bytes.NewReader(data))
+	if err != nil {
+		return errors.Wrap(err, "failed to create telemetry request")
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return errors.Wrap(err, "failed to send telemetry")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return errors.Errorf("telemetry endpoint responded with %s", resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mxpv/podsync/pkg/feed"
+	"github.com/mxpv/podsync/pkg/model"
+)
+
+func TestPayloadFields(t *testing.T) {
+	// Adding a field to the payload must be a conscious decision, update this list only after
+	// making sure the new field can't carry URLs, identities, titles or IP addresses.
+	expected := []string{"instance_id", "version", "feeds", "features", "storage"}
+
+	var actual []string
+	typ := reflect.TypeOf(Payload{})
+	for i := 0; i < typ.NumField(); i++ {
+		actual = append(actual, typ.Field(i).Tag.Get("json"))
+	}
+
+	assert.EqualValues(t, expected, actual)
+}
+
+func TestNewPayload(t *testing.T) {
+	feeds := map[string]*feed.Config{
+		"secret_feed_id": {
+			URL:  "https://www.youtube.com/channel/UC_secret_channel",
+			OPML: true,
+			Custom: feed.Custom{
+				Title:             "Secret title",
+				DescriptionFooter: "{{.URL}}",
+			},
+		},
+		"B": {
+			URL:     "https://vimeo.com/groups/secret_group",
+			Filters: feed.Filters{Title: "secret"},
+		},
+		"C": {
+			URL: "https://www.youtube.com/playlist?list=PLsecret",
+		},
+	}
+
+	payload := NewPayload("123", "v1.0.0", "local", feeds)
+
+	assert.EqualValues(t, "123", payload.InstanceID)
+	assert.EqualValues(t, "v1.0.0", payload.Version)
+	assert.EqualValues(t, "local", payload.Storage)
+	assert.EqualValues(t, map[model.Provider]int{model.ProviderYoutube: 2, model.ProviderVimeo: 1}, payload.Feeds)
+	assert.EqualValues(t, FeatureOPML|FeatureFilters|FeatureDescriptionFooter, payload.Features)
+
+	data, err := json.Marshal(payload)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "secret")
+	assert.NotContains(t, string(data), "Secret")
+}
+
+func TestReporterSend(t *testing.T) {
+	var received Payload
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(body, &received))
+	}))
+	defer srv.Close()
+
+	payload := Payload{InstanceID: "123", Version: "dev", Feeds: map[model.Provider]int{model.ProviderYoutube: 1}, Storage: "s3"}
+
+	reporter := New(Config{Enabled: true, Endpoint: srv.URL}, payload)
+	require.NoError(t, reporter.send(context.Background()))
+
+	assert.EqualValues(t, payload, received)
+}
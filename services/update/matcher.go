@@ -2,6 +2,7 @@ package update
 
 import (
 	"regexp"
+	"strings"
 
 	"github.com/mxpv/podsync/pkg/feed"
 	"github.com/mxpv/podsync/pkg/model"
@@ -12,7 +13,7 @@ func matchRegexpFilter(pattern, str string, negative bool, logger log.FieldLogge
 	if pattern != "" {
 		matched, err := regexp.MatchString(pattern, str)
 		if err != nil {
-			logger.Warnf("pattern %q is not a valid")
+			logger.WithError(err).Warnf("pattern %q is not valid", pattern)
 		} else {
 			if matched == negative {
 				logger.Infof("skipping due to mismatch")
@@ -23,6 +24,32 @@ func matchRegexpFilter(pattern, str string, negative bool, logger log.FieldLogge
 	return true
 }
 
+func matchRegion(region string, episode *model.Episode, logger log.FieldLogger) bool {
+	if region == "" {
+		return true
+	}
+
+	for _, blocked := range episode.BlockedRegions {
+		if strings.EqualFold(blocked, region) {
+			logger.Infof("skipping due to region restriction")
+			return false
+		}
+	}
+
+	if len(episode.AllowedRegions) == 0 {
+		return true
+	}
+
+	for _, allowed := range episode.AllowedRegions {
+		if strings.EqualFold(allowed, region) {
+			return true
+		}
+	}
+
+	logger.Infof("skipping due to region restriction")
+	return false
+}
+
 func matchFilters(episode *model.Episode, filters *feed.Filters) bool {
 	logger := log.WithFields(log.Fields{"episode_id": episode.ID})
 	if !matchRegexpFilter(filters.Title, episode.Title, false, logger.WithField("filter", "title")) {
@@ -41,5 +68,9 @@ func matchFilters(episode *model.Episode, filters *feed.Filters) bool {
 		return false
 	}
 
+	if !matchRegion(filters.Region, episode, logger.WithField("filter", "region")) {
+		return false
+	}
+
 	return true
 }
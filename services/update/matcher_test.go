@@ -0,0 +1,36 @@
+package update
+
+import (
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mxpv/podsync/pkg/model"
+)
+
+func TestMatchRegion(t *testing.T) {
+	tests := []struct {
+		name    string
+		region  string
+		allowed []string
+		blocked []string
+		match   bool
+	}{
+		{"no filter", "", nil, []string{"US"}, true},
+		{"no restrictions", "US", nil, nil, true},
+		{"blocked", "US", nil, []string{"DE", "US"}, false},
+		{"blocked elsewhere", "US", nil, []string{"DE"}, true},
+		{"allowed", "US", []string{"CA", "US"}, nil, true},
+		{"not allowed", "US", []string{"CA"}, nil, false},
+		{"case insensitive", "us", []string{"US"}, nil, true},
+		{"case insensitive blocked", "Us", nil, []string{"US"}, false},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.name, func(t *testing.T) {
+			episode := &model.Episode{ID: "1", AllowedRegions: tst.allowed, BlockedRegions: tst.blocked}
+			assert.Equal(t, tst.match, matchRegion(tst.region, episode, log.StandardLogger()))
+		})
+	}
+}
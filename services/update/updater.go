@@ -5,8 +5,10 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"sort"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
@@ -34,6 +36,16 @@ type Manager struct {
 	fs         fs.Storage
 	feeds      map[string]*feed.Config
 	keys       map[model.Provider]feed.KeyProvider
+
+	// Placeholder artwork
+	logo    *feed.Logo
+	client  *http.Client
+	artwork map[string]bool // Whether provider artwork is large enough, by URL
+
+	// Timers to publish withheld episodes, see NotifyPending
+	lock    sync.Mutex
+	timers  map[string]*time.Timer
+	pending func(feedConfig *feed.Config)
 }
 
 func NewUpdater(
@@ -51,9 +63,32 @@ func NewUpdater(
 		fs:         fs,
 		feeds:      feeds,
 		keys:       keys,
+		client:     &http.Client{Timeout: 30 * time.Second},
+		artwork:    map[string]bool{},
+		timers:     map[string]*time.Timer{},
 	}, nil
 }
 
+// SetLogo sets instance logo drawn on placeholder artwork.
+func (u *Manager) SetLogo(logo *feed.Logo) {
+	u.logo = logo
+}
+
+// NotifyPending registers a callback invoked when episodes withheld by publish_delay become due,
+// so the caller can Rebuild the feed without waiting for the next update.
+func (u *Manager) NotifyPending(fn func(feedConfig *feed.Config)) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	u.pending = fn
+}
+
+// Rebuild regenerates feed files from the database, without querying the provider or downloading episodes.
+func (u *Manager) Rebuild(ctx context.Context, feedConfig *feed.Config) error {
+	log.Infof("-> rebuilding %s", feedConfig.ID)
+	return u.buildXML(ctx, feedConfig)
+}
+
 func (u *Manager) Update(ctx context.Context, feedConfig *feed.Config) error {
 	log.WithFields(log.Fields{
 		"feed_id": feedConfig.ID,
@@ -130,6 +165,12 @@ func (u *Manager) updateFeed(ctx context.Context, feedConfig *feed.Config) error
 	}
 
 	for _, episode := range result.Episodes {
+		// AddFeed keeps existing episodes as is, refresh provider metadata that may change between updates
+		_, pending := episodeSet[episode.ID]
+		if err := u.db.UpdateEpisode(feedConfig.ID, episode.ID, refreshEpisode(episode, pending)); err != nil {
+			return err
+		}
+
 		delete(episodeSet, episode.ID)
 	}
 
@@ -146,6 +187,23 @@ func (u *Manager) updateFeed(ctx context.Context, feedConfig *feed.Config) error
 	return nil
 }
 
+// refreshEpisode updates title and description (e.g. after changing feed language).
+// Region restrictions are refreshed for episodes still to be downloaded (pending),
+// so region filter applies to episodes stored before the restrictions were recorded.
+func refreshEpisode(latest *model.Episode, pending bool) func(episode *model.Episode) error {
+	return func(episode *model.Episode) error {
+		episode.Title = latest.Title
+		episode.Description = latest.Description
+
+		if pending {
+			episode.AllowedRegions = latest.AllowedRegions
+			episode.BlockedRegions = latest.BlockedRegions
+		}
+
+		return nil
+	}
+}
+
 func (u *Manager) downloadEpisodes(ctx context.Context, feedConfig *feed.Config) error {
 	var (
 		feedID       = feedConfig.ID
@@ -260,10 +318,13 @@ func (u *Manager) downloadEpisodes(ctx context.Context, feedConfig *feed.Config)
 		}
 
 		// Update file status in database
+		// Downloader may adjust duration when post-processing (e.g. intro/outro added)
+		duration := episode.Duration
 
 		logger.Infof("successfully downloaded file %q", episode.ID)
 		if err := u.db.UpdateEpisode(feedID, episode.ID, func(episode *model.Episode) error {
 			episode.Size = fileSize
+			episode.Duration = duration
 			episode.Status = model.EpisodeDownloaded
 			return nil
 		}); err != nil {
@@ -283,6 +344,22 @@ func (u *Manager) buildXML(ctx context.Context, feedConfig *feed.Config) error {
 		return err
 	}
 
+	if !u.usableArtwork(ctx, feedConfig, feed.CoverArt(f, feedConfig, time.Now())) {
+		if err := u.placeholderArt(ctx, feedConfig, f); err != nil {
+			log.WithError(err).Warn("failed to create placeholder artwork")
+		}
+	}
+
+	count, next := feed.Pending(f, feedConfig, time.Now())
+	if count > 0 {
+		log.WithFields(log.Fields{
+			"pending": count,
+			"next":    next.Format(time.RFC3339),
+		}).Info("withholding episodes until publish delay elapses")
+	}
+
+	u.schedulePending(feedConfig, next)
+
 	// Build iTunes XML feed with data received from builder
 	log.Debug("building iTunes podcast feed")
 	podcast, err := feed.Build(ctx, f, feedConfig, u.hostname)
@@ -299,6 +376,118 @@ func (u *Manager) buildXML(ctx context.Context, feedConfig *feed.Config) error {
 		return errors.Wrap(err, "failed to upload new XML feed")
 	}
 
+	if feedConfig.Atom {
+		log.Debug("building Atom feed")
+		atom, err := feed.BuildAtom(ctx, f, feedConfig, u.hostname)
+		if err != nil {
+			return err
+		}
+
+		atomName := fmt.Sprintf("%s.atom", feedConfig.ID)
+		if _, err := u.fs.Create(ctx, atomName, bytes.NewReader(atom)); err != nil {
+			return errors.Wrap(err, "failed to upload new Atom feed")
+		}
+	}
+
+	if feedConfig.JSON {
+		log.Debug("building JSON feed")
+		data, err := feed.BuildJSON(ctx, f, feedConfig, u.hostname)
+		if err != nil {
+			return err
+		}
+
+		jsonName := fmt.Sprintf("%s.json", feedConfig.ID)
+		if _, err := u.fs.Create(ctx, jsonName, bytes.NewReader(data)); err != nil {
+			return errors.Wrap(err, "failed to upload new JSON feed")
+		}
+	}
+
+	return nil
+}
+
+// schedulePending replaces feed's publish timer, zero time cancels it.
+func (u *Manager) schedulePending(feedConfig *feed.Config, next time.Time) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	if timer, ok := u.timers[feedConfig.ID]; ok {
+		timer.Stop()
+		delete(u.timers, feedConfig.ID)
+	}
+
+	if next.IsZero() || u.pending == nil {
+		return
+	}
+
+	notify := u.pending
+	u.timers[feedConfig.ID] = time.AfterFunc(time.Until(next), func() {
+		notify(feedConfig)
+	})
+}
+
+// usableArtwork reports whether podcast directories accept feed artwork.
+// Custom cover art is always used as is.
+func (u *Manager) usableArtwork(ctx context.Context, feedConfig *feed.Config, url string) bool {
+	if feedConfig.Custom.CoverArt != "" {
+		return true
+	}
+
+	if url == "" {
+		return false
+	}
+
+	u.lock.Lock()
+	usable, ok := u.artwork[url]
+	u.lock.Unlock()
+
+	if ok {
+		return usable
+	}
+
+	width, height, err := feed.ArtworkSize(ctx, u.client, url)
+	if err != nil {
+		// Keep provider artwork rather than replacing it because of a temporary failure
+		log.WithError(err).Warnf("failed to check artwork size %q", url)
+		return true
+	}
+
+	usable = width >= feed.MinArtworkSize && height >= feed.MinArtworkSize
+	if !usable {
+		log.Infof("artwork is too small (%dx%d), using placeholder", width, height)
+	}
+
+	u.lock.Lock()
+	u.artwork[url] = usable
+	u.lock.Unlock()
+
+	return usable
+}
+
+// placeholderArt stores generated artwork for feeds without usable images.
+// File names depend on the title and the logo, so images are only rendered when either changes.
+func (u *Manager) placeholderArt(ctx context.Context, feedConfig *feed.Config, f *model.Feed) error {
+	title := f.Title
+	if feedConfig.Custom.Title != "" {
+		title = feedConfig.Custom.Title
+	}
+
+	for _, size := range feed.PlaceholderSizes {
+		name := fmt.Sprintf("%s/%s", feedConfig.ID, feed.PlaceholderName(title, u.logo, size))
+		if _, err := u.fs.Size(ctx, name); err == nil {
+			continue
+		}
+
+		data, err := feed.PlaceholderArt(title, u.logo, size)
+		if err != nil {
+			return err
+		}
+
+		if _, err := u.fs.Create(ctx, name, bytes.NewReader(data)); err != nil {
+			return errors.Wrap(err, "failed to upload placeholder artwork")
+		}
+	}
+
+	f.CoverArt = feed.FileURL(u.hostname, feedConfig, feed.PlaceholderName(title, u.logo, feed.MaxArtworkSize))
 	return nil
 }
 
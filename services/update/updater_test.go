@@ -0,0 +1,136 @@
+package update
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mxpv/podsync/pkg/feed"
+	"github.com/mxpv/podsync/pkg/fs"
+	"github.com/mxpv/podsync/pkg/model"
+)
+
+func TestRefreshEpisode(t *testing.T) {
+	latest := &model.Episode{ID: "1", Title: "título", Description: "descripción", BlockedRegions: []string{"US"}}
+
+	stored := &model.Episode{ID: "1", Title: "title", Status: model.EpisodeError, Size: 42}
+	assert.NoError(t, refreshEpisode(latest, true)(stored))
+	assert.Equal(t, []string{"US"}, stored.BlockedRegions)
+	assert.Empty(t, stored.AllowedRegions)
+	assert.Equal(t, "título", stored.Title)
+	assert.Equal(t, "descripción", stored.Description)
+	assert.Equal(t, model.EpisodeError, stored.Status)
+	assert.EqualValues(t, 42, stored.Size)
+
+	// Downloaded episodes only get new metadata
+	stored = &model.Episode{ID: "1", Title: "title", Status: model.EpisodeDownloaded}
+	assert.NoError(t, refreshEpisode(latest, false)(stored))
+	assert.Equal(t, "título", stored.Title)
+	assert.Empty(t, stored.BlockedRegions)
+	assert.Equal(t, model.EpisodeDownloaded, stored.Status)
+}
+
+func TestSchedulePending(t *testing.T) {
+	u, err := NewUpdater(nil, nil, "", nil, nil, nil)
+	require.NoError(t, err)
+
+	due := make(chan string, 2)
+	u.NotifyPending(func(feedConfig *feed.Config) {
+		due <- feedConfig.ID
+	})
+
+	cfg := &feed.Config{ID: "test"}
+
+	// Rescheduling replaces previous timer
+	u.schedulePending(cfg, time.Now().Add(time.Hour))
+	u.schedulePending(cfg, time.Now().Add(10*time.Millisecond))
+
+	select {
+	case id := <-due:
+		assert.Equal(t, "test", id)
+	case <-time.After(time.Second):
+		require.FailNow(t, "rebuild was not requested")
+	}
+
+	// Nothing pending cancels the timer
+	u.schedulePending(cfg, time.Now().Add(10*time.Millisecond))
+	u.schedulePending(cfg, time.Time{})
+
+	select {
+	case <-due:
+		assert.Fail(t, "canceled timer must not fire")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestPlaceholderArt(t *testing.T) {
+	dir, err := ioutil.TempDir("", "podsync-update-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	storage, err := fs.NewLocal(dir)
+	require.NoError(t, err)
+
+	avatars := map[string]int{"/small.png": 88, "/large.png": feed.MinArtworkSize}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		size, ok := avatars[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		require.NoError(t, png.Encode(w, image.NewRGBA(image.Rect(0, 0, size, size))))
+	}))
+	defer server.Close()
+
+	u, err := NewUpdater(nil, nil, "http://localhost", nil, nil, storage)
+	require.NoError(t, err)
+
+	var (
+		ctx = context.Background()
+		cfg = &feed.Config{ID: "test"}
+	)
+
+	assert.False(t, u.usableArtwork(ctx, cfg, ""))
+	assert.False(t, u.usableArtwork(ctx, cfg, server.URL+"/small.png"))
+	assert.True(t, u.usableArtwork(ctx, cfg, server.URL+"/large.png"))
+	assert.True(t, u.usableArtwork(ctx, &feed.Config{Custom: feed.Custom{CoverArt: "custom"}}, "custom"))
+
+	// Results are cached, so artwork isn't fetched on every build
+	server.Close()
+	assert.False(t, u.usableArtwork(ctx, cfg, server.URL+"/small.png"))
+
+	f := &model.Feed{Title: "channel"}
+	require.NoError(t, u.placeholderArt(ctx, cfg, f))
+	assert.Equal(t, "http://localhost/test/"+feed.PlaceholderName("channel", nil, feed.MaxArtworkSize), f.CoverArt)
+
+	for _, size := range feed.PlaceholderSizes {
+		data, err := ioutil.ReadFile(filepath.Join(dir, "test", feed.PlaceholderName("channel", nil, size)))
+		require.NoError(t, err)
+
+		config, err := png.DecodeConfig(bytes.NewReader(data))
+		require.NoError(t, err)
+		assert.Equal(t, size, config.Width)
+		assert.Equal(t, size, config.Height)
+	}
+
+	// Existing artwork is not rendered again
+	name := filepath.Join(dir, "test", feed.PlaceholderName("channel", nil, feed.MaxArtworkSize))
+	require.NoError(t, ioutil.WriteFile(name, []byte("cached"), 0644))
+	require.NoError(t, u.placeholderArt(ctx, cfg, f))
+
+	data, err := ioutil.ReadFile(name)
+	require.NoError(t, err)
+	assert.Equal(t, "cached", string(data))
+}
@@ -0,0 +1,121 @@
+package web
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+)
+
+// FeedAccess restricts who can fetch a feed.
+type FeedAccess struct {
+	// Token is required as ?token= query parameter for feed files, episodes and episodes directory
+	Token string
+	// Username and Password are required via HTTP Basic auth for feed files only,
+	// so podcast apps can download episodes without sending credentials.
+	Username string
+	Password string
+}
+
+// withFeedAccess enforces per-feed access restrictions.
+// Missing or wrong tokens get 404, so the response doesn't confirm that the feed exists.
+// Feed IDs are matched case-insensitively, as storage may live on a case-insensitive file system.
+func withFeedAccess(prefix string, feeds map[string]FeedAccess, next http.Handler) http.Handler {
+	feeds = normalizeFeedIDs(feeds)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var (
+			name   = strings.Trim(strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/"+prefix), "/")
+			feedID = feedIDFromPath(prefix, r.URL.Path)
+		)
+
+		if feedID == "" {
+			// Episodes directory listing (/{ID}/)
+			feedID = name
+		}
+
+		access, ok := feeds[strings.ToLower(feedID)]
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if access.Token != "" && !secureCompare(r.URL.Query().Get("token"), access.Token) {
+			http.NotFound(w, r)
+			return
+		}
+
+		if access.Username != "" && !strings.Contains(name, "/") {
+			username, password, ok := r.BasicAuth()
+			if !ok || !secureCompare(username, access.Username) || !secureCompare(password, access.Password) {
+				w.Header().Set("WWW-Authenticate", `Basic realm="Podsync", charset="UTF-8"`)
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func normalizeFeedIDs(feeds map[string]FeedAccess) map[string]FeedAccess {
+	normalized := make(map[string]FeedAccess, len(feeds))
+	for id, access := range feeds {
+		normalized[strings.ToLower(id)] = access
+	}
+
+	return normalized
+}
+
+// hideProtected removes protected feed files and episode directories from directory listings.
+func hideProtected(fs http.FileSystem, feeds map[string]FeedAccess) http.FileSystem {
+	if len(feeds) == 0 {
+		return fs
+	}
+
+	return &protectedFileSystem{FileSystem: fs, feeds: normalizeFeedIDs(feeds)}
+}
+
+type protectedFileSystem struct {
+	http.FileSystem
+	feeds map[string]FeedAccess
+}
+
+func (fs *protectedFileSystem) Open(name string) (http.File, error) {
+	file, err := fs.FileSystem.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &protectedFile{File: file, feeds: fs.feeds}, nil
+}
+
+type protectedFile struct {
+	http.File
+	feeds map[string]FeedAccess
+}
+
+func (f *protectedFile) Readdir(count int) ([]os.FileInfo, error) {
+	list, err := f.File.Readdir(count)
+
+	visible := list[:0]
+	for _, info := range list {
+		feedID := info.Name()
+		if !info.IsDir() {
+			feedID = feedIDFromPath("", info.Name())
+		}
+
+		if _, ok := f.feeds[strings.ToLower(feedID)]; ok {
+			continue
+		}
+
+		visible = append(visible, info)
+	}
+
+	return visible, err
+}
+
+func secureCompare(given, expected string) bool {
+	return subtle.ConstantTimeCompare([]byte(given), []byte(expected)) == 1
+}
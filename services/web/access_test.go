@@ -0,0 +1,121 @@
+package web
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeedAccessToken(t *testing.T) {
+	dir, err := ioutil.TempDir("", "podsync-web-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "private"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "private.xml"), []byte("<rss></rss>"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "private", "1.mp3"), []byte("mp3"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "public.xml"), []byte("<rss></rss>"), 0644))
+
+	var (
+		feeds   = map[string]FeedAccess{"private": {Token: "0123456789abcdef"}}
+		handler = withFeedAccess("", feeds, http.FileServer(hideProtected(http.Dir(dir), feeds)))
+	)
+
+	tests := []struct {
+		url  string
+		code int
+	}{
+		{"/private.xml", http.StatusNotFound},
+		{"/private.xml?token=wrong", http.StatusNotFound},
+		{"/private.xml?token=0123456789abcdef", http.StatusOK},
+		{"/private/1.mp3", http.StatusNotFound},
+		{"/private/1.mp3?token=0123456789abcdef", http.StatusOK},
+		{"/private/", http.StatusNotFound},
+		{"/public.xml", http.StatusOK},
+	}
+
+	for _, tst := range tests {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tst.url, nil))
+		assert.Equal(t, tst.code, rec.Code, tst.url)
+	}
+
+	// Root listing must not reveal protected feeds
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "public.xml")
+	assert.NotContains(t, rec.Body.String(), "private")
+
+	// Case-insensitive file systems (macOS, Windows) serve /PRIVATE.xml from private.xml
+	anyCase := withFeedAccess("", feeds, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	for _, url := range []string{
+		"/PRIVATE.xml", "/Private.atom", "/PRIVATE/1.mp3", "/Private/",
+		"/private.XML", "/PRIVATE.Xml", "/private.ATOM", "/private.Json", "/private.JSON",
+		"/private/1.MP3", "/Private/1.Mp4",
+	} {
+		rec := httptest.NewRecorder()
+		anyCase.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code, url)
+	}
+}
+
+func TestFeedAccessBasicAuth(t *testing.T) {
+	dir, err := ioutil.TempDir("", "podsync-web-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "private"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "private.xml"), []byte("<rss></rss>"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "private", "1.mp3"), []byte("mp3"), 0644))
+
+	handler := withFeedAccess("", map[string]FeedAccess{"private": {Username: "user", Password: "pass"}}, http.FileServer(http.Dir(dir)))
+
+	tests := []struct {
+		url      string
+		username string
+		password string
+		code     int
+	}{
+		{"/private.xml", "", "", http.StatusUnauthorized},
+		{"/private.xml", "user", "wrong", http.StatusUnauthorized},
+		{"/private.xml", "wrong", "pass", http.StatusUnauthorized},
+		{"/private.xml", "user", "pass", http.StatusOK},
+		// Episodes stay reachable for apps that don't send credentials with downloads
+		{"/private/1.mp3", "", "", http.StatusOK},
+	}
+
+	for _, tst := range tests {
+		req := httptest.NewRequest(http.MethodGet, tst.url, nil)
+		if tst.username != "" {
+			req.SetBasicAuth(tst.username, tst.password)
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, tst.code, rec.Code, "%s (%s:%s)", tst.url, tst.username, tst.password)
+
+		if tst.code == http.StatusUnauthorized {
+			assert.Contains(t, rec.Header().Get("WWW-Authenticate"), "Basic")
+		}
+	}
+}
+
+func TestFeedAccessBasicAuthAnyCase(t *testing.T) {
+	var (
+		feeds   = map[string]FeedAccess{"private": {Username: "user", Password: "pass"}}
+		handler = withFeedAccess("", feeds, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	)
+
+	for _, url := range []string{"/private.XML", "/PRIVATE.Xml", "/private.ATOM", "/Private.JSON", "/PRIVATE/"} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, url)
+	}
+}
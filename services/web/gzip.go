@@ -0,0 +1,133 @@
+package web
+
+import (
+	"compress/gzip"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// compressible is the list of generated text files worth compressing.
+// Media files are already compressed and must keep Range support.
+var compressible = map[string]bool{
+	".xml":  true,
+	".atom": true,
+	".opml": true,
+	".json": true,
+}
+
+// withGzip compresses feed responses for clients that accept gzip encoding.
+func withGzip(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !compressible[strings.ToLower(path.Ext(r.URL.Path))] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Add("Vary", "Accept-Encoding")
+
+		if !acceptsGzip(r) || r.Header.Get("Range") != "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// Compressed representation must not share validator with the identity one
+		if etag := w.Header().Get("ETag"); strings.HasSuffix(etag, `"`) {
+			w.Header().Set("ETag", strings.TrimSuffix(etag, `"`)+`-gzip"`)
+		}
+
+		gw := &gzipResponseWriter{ResponseWriter: w, head: r.Method == http.MethodHead}
+		defer gw.Close()
+
+		next.ServeHTTP(gw, r)
+	})
+}
+
+// acceptsGzip reports whether the client accepts gzip with a non-zero quality value (RFC 7231, section 5.3.4).
+func acceptsGzip(r *http.Request) bool {
+	for _, encoding := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		params := strings.Split(encoding, ";")
+		if !strings.EqualFold(strings.TrimSpace(params[0]), "gzip") {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range params[1:] {
+			kv := strings.SplitN(param, "=", 2)
+			if len(kv) != 2 || !strings.EqualFold(strings.TrimSpace(kv[0]), "q") {
+				continue
+			}
+
+			q, err := strconv.ParseFloat(strings.TrimSpace(kv[1]), 64)
+			if err != nil {
+				// Malformed weight, don't guess
+				q = 0
+			}
+
+			quality = q
+		}
+
+		return quality > 0
+	}
+
+	return false
+}
+
+// gzipResponseWriter compresses successful responses only,
+// so 304 and error responses as well as HEAD requests are passed through as is.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	gz          *gzip.Writer
+	head        bool
+	wroteHeader bool
+	passThrough bool
+}
+
+func (w *gzipResponseWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+
+	w.wroteHeader = true
+
+	header := w.Header()
+	if code != http.StatusOK || header.Get("Content-Encoding") != "" {
+		// Either nothing to compress, or already encoded (e.g. by a reverse proxy in front of storage)
+		w.passThrough = true
+	} else {
+		header.Set("Content-Encoding", "gzip")
+		header.Del("Content-Length")
+	}
+
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *gzipResponseWriter) Write(data []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if w.passThrough {
+		return w.ResponseWriter.Write(data)
+	}
+
+	if w.gz == nil {
+		w.gz = gzip.NewWriter(w.ResponseWriter)
+	}
+
+	return w.gz.Write(data)
+}
+
+func (w *gzipResponseWriter) Close() error {
+	if !w.wroteHeader || w.passThrough || w.head {
+		return nil
+	}
+
+	if w.gz == nil {
+		// Empty body still needs a valid gzip stream
+		w.gz = gzip.NewWriter(w.ResponseWriter)
+	}
+
+	return w.gz.Close()
+}
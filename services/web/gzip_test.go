@@ -0,0 +1,163 @@
+package web
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupFiles(t testing.TB) (http.Handler, string, func()) {
+	dir, err := ioutil.TempDir("", "podsync-web-")
+	require.NoError(t, err)
+
+	var feed strings.Builder
+	feed.WriteString("<rss><channel>")
+	for i := 0; i < 150; i++ {
+		fmt.Fprintf(&feed, "<item><title>Episode %d</title><description>%s</description></item>", i, strings.Repeat("Lorem ipsum dolor sit amet. ", 40))
+	}
+	feed.WriteString("</channel></rss>")
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "feed.xml"), []byte(feed.String()), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "episode.mp3"), []byte(feed.String()), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "empty.xml"), nil, 0644))
+
+	storage := http.Dir(dir)
+	return withETag(storage, withGzip(http.FileServer(storage))), feed.String(), func() { os.RemoveAll(dir) }
+}
+
+func TestGzip(t *testing.T) {
+	handler, content, cleanup := setupFiles(t)
+	defer cleanup()
+
+	req := httptest.NewRequest(http.MethodGet, "/feed.xml", nil)
+	req.Header.Set("Accept-Encoding", "gzip, deflate")
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+	assert.Contains(t, rec.Header().Get("Vary"), "Accept-Encoding")
+	assert.True(t, strings.HasSuffix(rec.Header().Get("ETag"), `-gzip"`))
+	assert.Less(t, rec.Body.Len(), len(content)/10)
+
+	reader, err := gzip.NewReader(rec.Body)
+	require.NoError(t, err)
+	data, err := ioutil.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(data))
+
+	// Compressed variant is revalidated with its own ETag
+	req.Header.Set("If-None-Match", rec.Header().Get("ETag"))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Empty(t, rec.Header().Get("Content-Encoding"))
+	assert.Zero(t, rec.Body.Len())
+}
+
+func TestGzipIdentity(t *testing.T) {
+	handler, content, cleanup := setupFiles(t)
+	defer cleanup()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/feed.xml", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get("Content-Encoding"))
+	assert.Equal(t, content, rec.Body.String())
+}
+
+func TestAcceptsGzip(t *testing.T) {
+	tests := []struct {
+		header string
+		accept bool
+	}{
+		{"", false},
+		{"gzip", true},
+		{"GZIP", true},
+		{"gzip, deflate", true},
+		{"deflate, gzip;q=0.5", true},
+		{"gzip;q=1.0", true},
+		{"gzip; q=0.001", true},
+		{"gzip;q=0", false},
+		{"gzip;q=0.0", false},
+		{"gzip; q=0.000", false},
+		{"gzip ; Q=0", false},
+		{"gzip;q=invalid", false},
+		{"deflate", false},
+		{"x-gzip2", false},
+	}
+
+	for _, tst := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/feed.xml", nil)
+		req.Header.Set("Accept-Encoding", tst.header)
+		assert.Equal(t, tst.accept, acceptsGzip(req), tst.header)
+	}
+}
+
+func TestGzipSkipsMedia(t *testing.T) {
+	handler, content, cleanup := setupFiles(t)
+	defer cleanup()
+
+	req := httptest.NewRequest(http.MethodGet, "/episode.mp3", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get("Content-Encoding"))
+	assert.Equal(t, content, rec.Body.String())
+}
+
+func TestGzipEmptyFile(t *testing.T) {
+	handler, _, cleanup := setupFiles(t)
+	defer cleanup()
+
+	req := httptest.NewRequest(http.MethodGet, "/empty.xml", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+
+	reader, err := gzip.NewReader(rec.Body)
+	require.NoError(t, err)
+	data, err := ioutil.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Empty(t, data)
+}
+
+func BenchmarkGzip(b *testing.B) {
+	handler, content, cleanup := setupFiles(b)
+	defer cleanup()
+
+	req := httptest.NewRequest(http.MethodGet, "/feed.xml", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	var size int
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		size = rec.Body.Len()
+	}
+
+	b.ReportMetric(float64(len(content)), "identity-bytes")
+	b.ReportMetric(float64(size), "gzip-bytes")
+}
@@ -0,0 +1,135 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	healthCheckTimeout = 2 * time.Second
+	healthCacheTTL     = 2 * time.Second
+)
+
+// HealthCheck verifies that a dependency is usable, it should be cheap as probes run it often.
+type HealthCheck func(ctx context.Context) error
+
+type healthReport struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+// healthHandler runs all checks with a short timeout and reports aggregated status.
+// Results are cached for a couple of seconds, so aggressive probes don't pile up on the dependencies.
+type healthHandler struct {
+	checks  map[string]HealthCheck
+	timeout time.Duration
+	ttl     time.Duration
+
+	lock      sync.Mutex
+	report    healthReport
+	checkedAt time.Time
+}
+
+func newHealthHandler(checks map[string]HealthCheck) *healthHandler {
+	return &healthHandler{
+		checks:  checks,
+		timeout: healthCheckTimeout,
+		ttl:     healthCacheTTL,
+	}
+}
+
+func (h *healthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	report := h.get()
+
+	code := http.StatusOK
+	if report.Status != "ok" {
+		code = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+
+	if r.Method == http.MethodHead {
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		log.WithError(err).Debug("failed to write health report")
+	}
+}
+
+func (h *healthHandler) get() healthReport {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if !h.checkedAt.IsZero() && time.Since(h.checkedAt) < h.ttl {
+		return h.report
+	}
+
+	// Results are shared between probes, so don't tie checks to the request that happened to run them
+	ctx, cancel := context.WithTimeout(context.Background(), h.timeout)
+	defer cancel()
+
+	names := make([]string, 0, len(h.checks))
+	for name := range h.checks {
+		names = append(names, name)
+	}
+
+	var (
+		wg      sync.WaitGroup
+		results = make([]error, len(names))
+	)
+
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, check HealthCheck) {
+			defer wg.Done()
+			results[i] = runCheck(ctx, check)
+		}(i, h.checks[name])
+	}
+
+	wg.Wait()
+
+	report := healthReport{Status: "ok", Checks: make(map[string]string, len(names))}
+	for i, name := range names {
+		if err := results[i]; err != nil {
+			log.WithError(err).Warnf("health check %q failed", name)
+			report.Status = "unavailable"
+			report.Checks[name] = err.Error()
+		} else {
+			report.Checks[name] = "ok"
+		}
+	}
+
+	h.report = report
+	h.checkedAt = time.Now()
+
+	return report
+}
+
+// runCheck doesn't wait for checks that ignore context cancellation.
+func runCheck(ctx context.Context, check HealthCheck) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- check(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
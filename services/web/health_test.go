@@ -0,0 +1,117 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func probe(t *testing.T, handler http.Handler) (int, healthReport) {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	var report healthReport
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+	return rec.Code, report
+}
+
+func TestHealth(t *testing.T) {
+	handler := newHealthHandler(map[string]HealthCheck{
+		"database": func(ctx context.Context) error { return nil },
+		"storage":  func(ctx context.Context) error { return nil },
+	})
+
+	code, report := probe(t, handler)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "ok", report.Status)
+	assert.EqualValues(t, map[string]string{"database": "ok", "storage": "ok"}, report.Checks)
+}
+
+func TestHealthFailure(t *testing.T) {
+	handler := newHealthHandler(map[string]HealthCheck{
+		"database": func(ctx context.Context) error { return errors.New("db is closed") },
+		"storage":  func(ctx context.Context) error { return nil },
+	})
+
+	code, report := probe(t, handler)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "unavailable", report.Status)
+	assert.Equal(t, "db is closed", report.Checks["database"])
+	assert.Equal(t, "ok", report.Checks["storage"])
+}
+
+func TestHealthTimeout(t *testing.T) {
+	hang := make(chan struct{})
+	defer close(hang)
+
+	handler := newHealthHandler(map[string]HealthCheck{
+		"database": func(ctx context.Context) error {
+			<-hang // Ignores context
+			return nil
+		},
+	})
+	handler.timeout = 50 * time.Millisecond
+
+	start := time.Now()
+	code, report := probe(t, handler)
+
+	assert.Less(t, int64(time.Since(start)), int64(time.Second))
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks["database"])
+}
+
+func TestHealthCache(t *testing.T) {
+	calls := 0
+	handler := newHealthHandler(map[string]HealthCheck{
+		"database": func(ctx context.Context) error {
+			calls++
+			return nil
+		},
+	})
+
+	for i := 0; i < 10; i++ {
+		code, _ := probe(t, handler)
+		assert.Equal(t, http.StatusOK, code)
+	}
+
+	assert.Equal(t, 1, calls)
+
+	handler.ttl = 0
+	probe(t, handler)
+	assert.Equal(t, 2, calls)
+}
+
+func TestHealthIgnoresCanceledProbe(t *testing.T) {
+	handler := newHealthHandler(map[string]HealthCheck{
+		"database": func(ctx context.Context) error {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(10 * time.Millisecond):
+				return nil
+			}
+		},
+	})
+
+	// Probe client gave up before checks finished
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil).WithContext(ctx))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// Next probe is served from cache and must not see a failure either
+	code, report := probe(t, handler)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "ok", report.Status)
+}
@@ -0,0 +1,149 @@
+package web
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const requestIDHeader = "X-Request-ID"
+
+// Incoming IDs end up in logs, so accept only reasonably short tokens
+var requestIDReg = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,64}$`)
+
+type requestIDKey struct{}
+
+// RequestID returns request ID stored in the context by the web server.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// withRequestLog assigns a request ID (or takes one from X-Request-ID header), echoes it back
+// to the client and writes one access log entry per request.
+func withRequestLog(prefix string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var (
+			start = time.Now()
+			id    = r.Header.Get(requestIDHeader)
+		)
+
+		if !requestIDReg.MatchString(id) {
+			id = newRequestID()
+		}
+
+		w.Header().Set(requestIDHeader, id)
+
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r.WithContext(context.WithValue(r.Context(), requestIDKey{}, id)))
+
+		fields := log.Fields{
+			"request_id": id,
+			"method":     r.Method,
+			"path":       r.URL.Path,
+			"status":     rec.status,
+			"bytes":      rec.bytes,
+			"latency":    time.Since(start).String(),
+		}
+
+		if feedID := feedIDFromPath(prefix, r.URL.Path); feedID != "" {
+			fields["feed_id"] = feedID
+		}
+
+		log.WithFields(fields).Info("request")
+	})
+}
+
+// feedIDFromPath extracts feed ID from both /{ID}.xml and /{ID}/{episode} paths.
+// Extensions are matched case-insensitively, as storage may live on a case-insensitive file system.
+func feedIDFromPath(prefix, urlPath string) string {
+	urlPath = strings.TrimPrefix(path.Clean("/"+urlPath), "/")
+	if prefix != "" {
+		urlPath = strings.TrimPrefix(strings.TrimPrefix(urlPath, prefix), "/")
+	}
+
+	if i := strings.IndexByte(urlPath, '/'); i >= 0 {
+		return urlPath[:i]
+	}
+
+	ext := path.Ext(urlPath)
+	switch strings.ToLower(ext) {
+	case ".xml", ".atom", ".json":
+		return strings.TrimSuffix(urlPath, ext)
+	}
+
+	return ""
+}
+
+func newRequestID() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "unknown"
+	}
+
+	return hex.EncodeToString(buf)
+}
+
+// statusRecorder captures response status and size for the access log.
+// It passes through optional interfaces, so http.FileServer keeps using sendfile via io.ReaderFrom
+// and handlers can still flush.
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	bytes       int64
+	wroteHeader bool
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	if !r.wroteHeader {
+		r.status = code
+		r.wroteHeader = true
+	}
+
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *statusRecorder) Write(p []byte) (int, error) {
+	r.wroteHeader = true
+	n, err := r.ResponseWriter.Write(p)
+	r.bytes += int64(n)
+	return n, err
+}
+
+func (r *statusRecorder) ReadFrom(src io.Reader) (int64, error) {
+	r.wroteHeader = true
+
+	var (
+		n   int64
+		err error
+	)
+
+	if rf, ok := r.ResponseWriter.(io.ReaderFrom); ok {
+		n, err = rf.ReadFrom(src)
+	} else {
+		// Hide ReadFrom from io.Copy, otherwise it calls back into this method
+		n, err = io.Copy(struct{ io.Writer }{r.ResponseWriter}, src)
+	}
+
+	r.bytes += n
+	return n, err
+}
+
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
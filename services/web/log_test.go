@@ -0,0 +1,110 @@
+package web
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestLog(t *testing.T) {
+	hook := test.NewGlobal()
+	defer hook.Reset()
+
+	var ctxID string
+	handler := withRequestLog("", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctxID = RequestID(r.Context())
+		http.NotFound(w, r)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/feed/episode.mp3", nil)
+	req.Header.Set("X-Request-ID", "abc-123")
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, "abc-123", rec.Header().Get("X-Request-ID"))
+	assert.Equal(t, "abc-123", ctxID)
+
+	entry := hook.LastEntry()
+	require.NotNil(t, entry)
+	assert.Equal(t, log.InfoLevel, entry.Level)
+	assert.Equal(t, "abc-123", entry.Data["request_id"])
+	assert.Equal(t, http.StatusNotFound, entry.Data["status"])
+	assert.Equal(t, "feed", entry.Data["feed_id"])
+}
+
+func TestRequestLogGeneratesID(t *testing.T) {
+	handler := withRequestLog("", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	for _, incoming := range []string{"", "bad id\nwith newline"} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-Request-ID", incoming)
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		id := rec.Header().Get("X-Request-ID")
+		assert.Len(t, id, 16)
+		assert.NotEqual(t, incoming, id)
+	}
+}
+
+func TestFeedIDFromPath(t *testing.T) {
+	tests := []struct {
+		prefix string
+		path   string
+		feedID string
+	}{
+		{"", "/ID.xml", "ID"},
+		{"", "/ID.atom", "ID"},
+		{"", "/ID/episode.mp3", "ID"},
+		{"", "/ID.XML", "ID"},
+		{"", "/Id.Atom", "Id"},
+		{"", "/ID.JSON", "ID"},
+		{"", "/ID/episode.MP3", "ID"},
+		{"", "/ID.Png", ""},
+		{"", "/podsync.opml", ""},
+		{"", "/healthz", ""},
+		{"feeds", "/feeds/ID.xml", "ID"},
+		{"feeds", "/feeds/ID/episode.mp4", "ID"},
+	}
+
+	for _, tst := range tests {
+		assert.Equal(t, tst.feedID, feedIDFromPath(tst.prefix, tst.path), tst.path)
+	}
+}
+
+func TestRequestLogPassThrough(t *testing.T) {
+	hook := test.NewGlobal()
+	defer hook.Reset()
+
+	handler := withRequestLog("", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, ok := w.(io.ReaderFrom)
+		assert.True(t, ok, "ReaderFrom must be preserved for sendfile")
+
+		_, _ = w.Write([]byte("hello "))
+		_, _ = io.Copy(w, strings.NewReader("world"))
+
+		flusher, ok := w.(http.Flusher)
+		require.True(t, ok)
+		flusher.Flush()
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ID.xml", nil))
+
+	assert.Equal(t, "hello world", rec.Body.String())
+	assert.True(t, rec.Flushed)
+
+	entry := hook.LastEntry()
+	require.NotNil(t, entry)
+	assert.Equal(t, http.StatusOK, entry.Data["status"])
+	assert.EqualValues(t, 11, entry.Data["bytes"])
+}
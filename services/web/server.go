@@ -1,14 +1,24 @@
 package web
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"path"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 )
 
+const (
+	defaultReadTimeout     = 30 * time.Second
+	defaultIdleTimeout     = 2 * time.Minute
+	defaultShutdownTimeout = 5 * time.Second
+)
+
 type Server struct {
 	http.Server
+	shutdownTimeout time.Duration
 }
 
 type Config struct {
@@ -25,9 +35,20 @@ type Config struct {
 	// DataDir is a path to a directory to keep XML feeds and downloaded episodes,
 	// that will be available to user via web server for download.
 	DataDir string `toml:"data_dir"`
+	// ReadTimeout is the maximum duration for reading the entire request (30s by default)
+	ReadTimeout time.Duration `toml:"read_timeout"`
+	// WriteTimeout is the maximum duration for writing the response.
+	// Disabled by default, as downloading large episodes over slow connections may take a while.
+	WriteTimeout time.Duration `toml:"write_timeout"`
+	// IdleTimeout is how long to keep idle keep-alive connections open (2m by default)
+	IdleTimeout time.Duration `toml:"idle_timeout"`
+	// ShutdownTimeout is how long to wait for in-flight requests to finish on shutdown (5s by default)
+	ShutdownTimeout time.Duration `toml:"shutdown_timeout"`
 }
 
-func New(cfg Config, storage http.FileSystem) *Server {
+// New creates web server to host feeds and episodes from storage.
+// access maps feed IDs to restrictions for protected feeds.
+func New(cfg Config, storage http.FileSystem, access map[string]FeedAccess, checks map[string]HealthCheck) *Server {
 	port := cfg.Port
 	if port == 0 {
 		port = 8080
@@ -38,15 +59,59 @@ func New(cfg Config, storage http.FileSystem) *Server {
 		bindAddress = ""
 	}
 
-	srv := Server{}
+	srv := Server{shutdownTimeout: cfg.ShutdownTimeout}
+	if srv.shutdownTimeout == 0 {
+		srv.shutdownTimeout = defaultShutdownTimeout
+	}
 
 	srv.Addr = fmt.Sprintf("%s:%d", bindAddress, port)
+
+	srv.ReadTimeout = cfg.ReadTimeout
+	if srv.ReadTimeout == 0 {
+		srv.ReadTimeout = defaultReadTimeout
+	}
+
+	srv.WriteTimeout = cfg.WriteTimeout
+
+	srv.IdleTimeout = cfg.IdleTimeout
+	if srv.IdleTimeout == 0 {
+		srv.IdleTimeout = defaultIdleTimeout
+	}
 	log.Debugf("using address: %s:%s", bindAddress, srv.Addr)
 
-	fileServer := http.FileServer(storage)
+	fileServer := http.FileServer(hideProtected(storage, access))
 
 	log.Debugf("handle path: /%s", cfg.Path)
-	http.Handle(fmt.Sprintf("/%s", cfg.Path), fileServer)
+	http.Handle(fmt.Sprintf("/%s", cfg.Path), withFeedAccess(cfg.Path, access, withETag(storage, withGzip(fileServer))))
+	http.Handle("/healthz", newHealthHandler(checks))
+
+	srv.Handler = withRequestLog(cfg.Path, http.DefaultServeMux)
 
 	return &srv
 }
+
+// Stop stops accepting new connections and waits for in-flight requests to finish,
+// active connections are closed forcibly once shutdown timeout elapses.
+func (s *Server) Stop() error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+	defer cancel()
+
+	return s.Shutdown(ctx)
+}
+
+// withETag sets a validator derived from file's size and modification time, so clients
+// polling feeds get 304 Not Modified from the file server until the feed is rebuilt.
+func withETag(storage http.FileSystem, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			if file, err := storage.Open(path.Clean("/" + r.URL.Path)); err == nil {
+				if stat, err := file.Stat(); err == nil && !stat.IsDir() {
+					w.Header().Set("ETag", fmt.Sprintf(`"%x-%x"`, stat.ModTime().UnixNano(), stat.Size()))
+				}
+				file.Close()
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
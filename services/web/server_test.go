@@ -0,0 +1,161 @@
+package web
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestETag(t *testing.T) {
+	dir, err := ioutil.TempDir("", "podsync-web-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	feedPath := filepath.Join(dir, "feed.xml")
+	require.NoError(t, ioutil.WriteFile(feedPath, []byte("<rss></rss>"), 0644))
+
+	var (
+		storage = http.Dir(dir)
+		handler = withETag(storage, http.FileServer(storage))
+	)
+
+	get := func(etag string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/feed.xml", nil)
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Initial request
+	resp := get("")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	etag := resp.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	// Not modified
+	resp = get(etag)
+	assert.Equal(t, http.StatusNotModified, resp.Code)
+	assert.Empty(t, resp.Body.String())
+
+	// Feed rebuilt with a new episode
+	require.NoError(t, ioutil.WriteFile(feedPath, []byte("<rss><item></item></rss>"), 0644))
+	modTime := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(feedPath, modTime, modTime))
+
+	resp = get(etag)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.NotEqual(t, etag, resp.Header().Get("ETag"))
+	assert.Equal(t, "<rss><item></item></rss>", resp.Body.String())
+}
+
+func TestETagMissingFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "podsync-web-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	storage := http.Dir(dir)
+	rec := httptest.NewRecorder()
+	withETag(storage, http.FileServer(storage)).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/missing.xml", nil))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Empty(t, rec.Header().Get("ETag"))
+}
+
+func TestHead(t *testing.T) {
+	handler, _, cleanup := setupFiles(t)
+	defer cleanup()
+
+	for _, encoding := range []string{"", "gzip"} {
+		do := func(method, path string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(method, path, nil)
+			if encoding != "" {
+				req.Header.Set("Accept-Encoding", encoding)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			return rec
+		}
+
+		get := do(http.MethodGet, "/feed.xml")
+		head := do(http.MethodHead, "/feed.xml")
+
+		assert.Equal(t, http.StatusOK, head.Code)
+		assert.Zero(t, head.Body.Len())
+
+		for _, name := range []string{"Content-Type", "Content-Length", "Content-Encoding", "ETag", "Last-Modified"} {
+			assert.Equal(t, get.Header().Get(name), head.Header().Get(name), "%s (encoding %q)", name, encoding)
+		}
+
+		// Revalidation and missing feeds
+		req := httptest.NewRequest(http.MethodHead, "/feed.xml", nil)
+		req.Header.Set("Accept-Encoding", encoding)
+		req.Header.Set("If-None-Match", head.Header().Get("ETag"))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+
+		assert.Equal(t, http.StatusNotFound, do(http.MethodHead, "/missing.xml").Code)
+	}
+}
+
+func TestStopWaitsForInflightRequests(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	started := make(chan struct{})
+
+	srv := &Server{shutdownTimeout: 5 * time.Second}
+	srv.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		_, _ = w.Write([]byte("<rss></rss>"))
+	})
+
+	go func() {
+		_ = srv.Serve(listener)
+	}()
+
+	url := "http://" + listener.Addr().String() + "/feed.xml"
+
+	type result struct {
+		body string
+		err  error
+	}
+
+	done := make(chan result, 1)
+	go func() {
+		resp, err := http.Get(url)
+		if err != nil {
+			done <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+
+		body, err := ioutil.ReadAll(resp.Body)
+		done <- result{body: string(body), err: err}
+	}()
+
+	<-started
+	require.NoError(t, srv.Stop())
+
+	res := <-done
+	require.NoError(t, res.err)
+	assert.Equal(t, "<rss></rss>", res.body)
+
+	// New connections are rejected after shutdown
+	_, err = http.Get(url)
+	assert.Error(t, err)
+}